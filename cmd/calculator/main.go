@@ -65,11 +65,13 @@ func run() error {
 	calcSvc := service.NewCalculatorService(conf, log, calc.NewCalculator(), repo)
 	agentSvc := service.NewAgentService(conf, log, repo)
 	internalSvc := service.NewInternalService(conf, log, repo)
+	funcSvc := service.NewFunctionService(conf, log, calc.NewCalculator(), repo)
+	leaseReaper := service.NewLeaseReaper(conf, log, repo)
 
 	for i, svc := range []interface {
 		Register(*grpc.Server)
 		RegisterGRPCGateway(context.Context, *runtime.ServeMux, []grpc.DialOption) error
-	}{calcSvc, agentSvc, internalSvc} {
+	}{calcSvc, agentSvc, internalSvc, funcSvc} {
 		svc.Register(grpcSrv.GRPC)
 		if err := svc.RegisterGRPCGateway(ctx, httpSrv.GWMux, []grpc.DialOption{
 			grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -78,7 +80,7 @@ func run() error {
 		}
 	}
 
-	runy.Add(mgmtSrv, grpcSrv, httpSrv)
+	runy.Add(mgmtSrv, grpcSrv, httpSrv, leaseReaper)
 	if err := runy.Start(ctx); err != nil {
 		return fmt.Errorf("problem with running app: %w", err)
 	}
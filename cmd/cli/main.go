@@ -11,7 +11,7 @@ func main() {
 	s := "(2 + 2) + (1 + 1) * 3"
 
 	c := &calc.Calculator{}
-	rpn, err := c.Parse(s)
+	rpn, err := c.Parse(s, nil, nil)
 	if err != nil {
 		slog.Error("error", "error", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"math"
+	"math/big"
 	"sync"
 	"time"
 
@@ -16,7 +17,8 @@ import (
 )
 
 type CalculatorAgentAPIClient interface {
-	GetTask(ctx context.Context) (*calculatorv1.Task, error)
+	GetTask(ctx context.Context) (*calculatorv1.GetTaskResponse, error)
+	ExtendTaskLease(ctx context.Context, req *calculatorv1.ExtendTaskLeaseRequest) error
 	SubmitTaskResult(ctx context.Context, res *calculatorv1.SubmitTaskResultRequest) error
 }
 
@@ -68,35 +70,115 @@ func (a *Agent) worker(ctx context.Context, workerID int) {
 			log.InfoContext(ctx, "worker stopped")
 			return
 		default:
-			task, err := a.fetchTask(ctx, log)
+			resp, err := a.fetchTask(ctx, log)
 			if err != nil {
 				continue // context done
 			}
 
+			task := resp.Task
 			log := log.With("task_id", task.Id)
 			log.DebugContext(ctx, "executing task")
 
-			result, err := a.executeTask(ctx, task)
+			// The task is abandoned if its lease is lost, as another agent will execute it
+			taskCtx, cancel := context.WithCancel(ctx)
+			go a.extendLease(taskCtx, cancel, log, resp)
+
+			res, err := a.execute(taskCtx, task)
 			if err != nil {
-				continue // context done
+				cancel()
+				continue // context done or lease lost
 			}
+			res.LeaseToken = resp.LeaseToken
 
-			if err := a.submitTaskResult(ctx, log, task.Id, result); err != nil {
-				continue // context done
+			err = a.submitTaskResult(taskCtx, log, res)
+			cancel()
+			if err != nil {
+				continue // context done or lease lost
 			}
 
-			log.InfoContext(ctx, "task completed", "result", result)
+			log.InfoContext(ctx, "task completed", "result", res.Result, "exact_result", res.ExactResult)
 		}
 	}
 }
 
+// extendLease extends the lease of the task every third of the lease duration until the context is canceled.
+// It cancels the task if the lease is lost, e.g. it has expired while the agent was unavailable.
+func (a *Agent) extendLease(ctx context.Context, cancelTask context.CancelFunc, log *slog.Logger, resp *calculatorv1.GetTaskResponse) {
+	lease := resp.LeaseDuration.AsDuration()
+	if lease <= 0 {
+		return // the calculator doesn't lease tasks
+	}
+
+	ticker := time.NewTicker(lease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := a.client.ExtendTaskLease(ctx, &calculatorv1.ExtendTaskLeaseRequest{
+				Id:         resp.Task.Id,
+				LeaseToken: resp.LeaseToken,
+			})
+			if errors.Is(err, client.ErrLeaseLost) {
+				log.WarnContext(ctx, "task lease lost, abandoning task")
+				cancelTask()
+				return
+			}
+			if err != nil && ctx.Err() == nil {
+				log.ErrorContext(ctx, "failed to extend task lease", "error", err) // try again before the lease expires
+			}
+		}
+	}
+}
+
+// execute performs the task in its arithmetic and returns the result to submit.
+func (a *Agent) execute(ctx context.Context, task *calculatorv1.Task) (*calculatorv1.SubmitTaskResultRequest, error) {
+	switch task.Arithmetic {
+	case calculatorv1.Arithmetic_ARITHMETIC_EXACT:
+		res, err := a.executeExactTask(ctx, task)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			return &calculatorv1.SubmitTaskResultRequest{Id: task.Id, Result: math.NaN()}, nil
+		}
+		approx, _ := res.Float64()
+		return &calculatorv1.SubmitTaskResultRequest{Id: task.Id, Result: saturate(approx), ExactResult: res.RatString()}, nil
+	case calculatorv1.Arithmetic_ARITHMETIC_BIG_INT:
+		res, err := a.executeBigIntTask(ctx, task)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			return &calculatorv1.SubmitTaskResultRequest{Id: task.Id, Result: math.NaN()}, nil
+		}
+		approx, _ := new(big.Float).SetInt(res).Float64()
+		return &calculatorv1.SubmitTaskResultRequest{Id: task.Id, Result: saturate(approx), ExactResult: res.String()}, nil
+	}
+
+	res, err := a.executeTask(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	return &calculatorv1.SubmitTaskResultRequest{Id: task.Id, Result: res}, nil
+}
+
+// saturate replaces infinities with the largest finite numbers, as approximations of exact results
+// too large for float64 must still be valid results.
+func saturate(approx float64) float64 {
+	if math.IsInf(approx, 0) {
+		return math.Copysign(math.MaxFloat64, approx)
+	}
+	return approx
+}
+
 // executeTask performs the actual mathematical operation specified by the task.
 // It simulates computation time by waiting for the duration specified in the task.
 func (a *Agent) executeTask(ctx context.Context, task *calculatorv1.Task) (float64, error) {
-	select {
-	case <-ctx.Done():
-		return 0, ctx.Err()
-	case <-time.After(task.OperationTime.AsDuration()):
+	if err := a.simulateWork(ctx, task); err != nil {
+		return 0, err
 	}
 
 	switch task.Operation {
@@ -111,16 +193,148 @@ func (a *Agent) executeTask(ctx context.Context, task *calculatorv1.Task) (float
 			return math.NaN(), nil
 		}
 		return task.Arg1 / task.Arg2, nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_NEGATION:
+		return -task.Arg1, nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_POWER:
+		res := math.Pow(task.Arg1, task.Arg2)
+		if math.IsInf(res, 0) { // e.g. 0^-1 or overflow
+			return math.NaN(), nil
+		}
+		return res, nil // NaN for a negative base with a fractional exponent
+	case calculatorv1.TaskOperation_TASK_OPERATION_MODULO:
+		if task.Arg2 == 0 {
+			return math.NaN(), nil
+		}
+		res := math.Mod(task.Arg1, task.Arg2)
+		if res != 0 && (res < 0) != (task.Arg2 < 0) {
+			res += task.Arg2 // keep the sign of the divisor, consistent with the integer division
+		}
+		return res, nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_INTEGER_DIVISION:
+		if task.Arg2 == 0 {
+			return math.NaN(), nil
+		}
+		return math.Floor(task.Arg1 / task.Arg2), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_LESS:
+		return boolToFloat(task.Arg1 < task.Arg2), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_LESS_OR_EQUAL:
+		return boolToFloat(task.Arg1 <= task.Arg2), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_GREATER:
+		return boolToFloat(task.Arg1 > task.Arg2), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_GREATER_OR_EQUAL:
+		return boolToFloat(task.Arg1 >= task.Arg2), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_EQUAL:
+		return boolToFloat(task.Arg1 == task.Arg2), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_NOT_EQUAL:
+		return boolToFloat(task.Arg1 != task.Arg2), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_AND:
+		return boolToFloat(task.Arg1 != 0 && task.Arg2 != 0), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_OR:
+		return boolToFloat(task.Arg1 != 0 || task.Arg2 != 0), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_NOT:
+		return boolToFloat(task.Arg1 == 0), nil
+	default:
+		return a.executeFunction(task.Operation, task.Args), nil
+	}
+}
+
+// boolToFloat returns 1 for true and 0 for false, the results of comparisons and logical operations.
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// evaluateLogical performs a comparison or a logical operation over exact operands x and y,
+// given x.Cmp(y) and signs of x and y. It reports false as the second result for other operations.
+func evaluateLogical(op calculatorv1.TaskOperation, cmp, sign1, sign2 int) (bool, bool) {
+	switch op {
+	case calculatorv1.TaskOperation_TASK_OPERATION_LESS:
+		return cmp < 0, true
+	case calculatorv1.TaskOperation_TASK_OPERATION_LESS_OR_EQUAL:
+		return cmp <= 0, true
+	case calculatorv1.TaskOperation_TASK_OPERATION_GREATER:
+		return cmp > 0, true
+	case calculatorv1.TaskOperation_TASK_OPERATION_GREATER_OR_EQUAL:
+		return cmp >= 0, true
+	case calculatorv1.TaskOperation_TASK_OPERATION_EQUAL:
+		return cmp == 0, true
+	case calculatorv1.TaskOperation_TASK_OPERATION_NOT_EQUAL:
+		return cmp != 0, true
+	case calculatorv1.TaskOperation_TASK_OPERATION_AND:
+		return sign1 != 0 && sign2 != 0, true
+	case calculatorv1.TaskOperation_TASK_OPERATION_OR:
+		return sign1 != 0 || sign2 != 0, true
+	default:
+		return false, false
+	}
+}
+
+// executeFunction evaluates a function operation over its arguments.
+// It returns NaN for unknown functions, a wrong number of arguments and domain errors.
+func (a *Agent) executeFunction(op calculatorv1.TaskOperation, args []float64) float64 {
+	if len(args) == 0 {
+		return math.NaN()
+	}
+
+	switch op {
+	case calculatorv1.TaskOperation_TASK_OPERATION_SQRT:
+		if len(args) != 1 || args[0] < 0 {
+			return math.NaN()
+		}
+		return math.Sqrt(args[0])
+	case calculatorv1.TaskOperation_TASK_OPERATION_ABS:
+		if len(args) != 1 {
+			return math.NaN()
+		}
+		return math.Abs(args[0])
+	case calculatorv1.TaskOperation_TASK_OPERATION_MIN:
+		res := args[0]
+		for _, arg := range args[1:] {
+			res = math.Min(res, arg)
+		}
+		return res
+	case calculatorv1.TaskOperation_TASK_OPERATION_MAX:
+		res := args[0]
+		for _, arg := range args[1:] {
+			res = math.Max(res, arg)
+		}
+		return res
+	case calculatorv1.TaskOperation_TASK_OPERATION_ROUND:
+		if len(args) != 1 {
+			return math.NaN()
+		}
+		return math.Round(args[0])
+	case calculatorv1.TaskOperation_TASK_OPERATION_LOG:
+		if len(args) > 2 || args[0] <= 0 {
+			return math.NaN()
+		}
+		if len(args) == 1 {
+			return math.Log(args[0])
+		}
+		if base := args[1]; base <= 0 || base == 1 {
+			return math.NaN()
+		}
+		return math.Log(args[0]) / math.Log(args[1])
+	case calculatorv1.TaskOperation_TASK_OPERATION_IF:
+		if len(args) != 3 {
+			return math.NaN()
+		}
+		if args[0] != 0 {
+			return args[1]
+		}
+		return args[2]
 	default:
-		return math.NaN(), nil
+		return math.NaN()
 	}
 }
 
-// fetchTask retrieves a pending task from the remote API with exponential backoff.
+// fetchTask retrieves a pending task and its lease from the remote API with exponential backoff.
 // It will retry indefinitely until the context is canceled or a task is obtained.
-func (a *Agent) fetchTask(ctx context.Context, log *slog.Logger) (*calculatorv1.Task, error) {
+func (a *Agent) fetchTask(ctx context.Context, log *slog.Logger) (*calculatorv1.GetTaskResponse, error) {
 	task, _ := retry.DoWithData(
-		func() (*calculatorv1.Task, error) {
+		func() (*calculatorv1.GetTaskResponse, error) {
 			return a.client.GetTask(ctx)
 		},
 		retry.OnRetry(func(attempt uint, err error) {
@@ -139,17 +353,27 @@ func (a *Agent) fetchTask(ctx context.Context, log *slog.Logger) (*calculatorv1.
 	return task, ctx.Err()
 }
 
-// submitTaskResult sends the computed result back to the API with exponential backoff.
-// It will retry indefinitely until the context is canceled or the submission succeeds.
-func (a *Agent) submitTaskResult(ctx context.Context, log *slog.Logger, taskID string, result float64) error {
-	req := &calculatorv1.SubmitTaskResultRequest{
-		Id:     taskID,
-		Result: result,
+// simulateWork waits for the operation time of the task. Returns an error if the context is done earlier.
+func (a *Agent) simulateWork(ctx context.Context, task *calculatorv1.Task) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(task.OperationTime.AsDuration()):
+		return nil
 	}
-	_ = retry.Do(
+}
+
+// submitTaskResult sends the computed result back to the API with exponential backoff.
+// It will retry indefinitely until the context is canceled, the submission succeeds or the lease is lost,
+// in which case it returns client.ErrLeaseLost.
+func (a *Agent) submitTaskResult(ctx context.Context, log *slog.Logger, req *calculatorv1.SubmitTaskResultRequest) error {
+	err := retry.Do(
 		func() error {
 			return a.client.SubmitTaskResult(ctx, req)
 		},
+		retry.RetryIf(func(err error) bool {
+			return !errors.Is(err, client.ErrLeaseLost)
+		}),
 		retry.OnRetry(func(attempt uint, err error) {
 			log.ErrorContext(ctx, "failed to submit task result", "error", err, "attempt", attempt)
 		}),
@@ -159,5 +383,9 @@ func (a *Agent) submitTaskResult(ctx context.Context, log *slog.Logger, taskID s
 		retry.MaxDelay(10*time.Second),
 		retry.MaxJitter(1*time.Second),
 	)
+	if errors.Is(err, client.ErrLeaseLost) {
+		log.WarnContext(ctx, "task lease lost, result discarded")
+		return err
+	}
 	return ctx.Err()
 }
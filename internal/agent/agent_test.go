@@ -99,6 +99,343 @@ func TestAgent_executeTask(t *testing.T) {
 			wantNaN: true,
 			wantErr: assert.NoError,
 		},
+		{
+			name: "negation operation",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task8",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_NEGATION,
+					Arg1:      9,
+				},
+			},
+			want:    -9,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "power operation",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task9",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_POWER,
+					Arg1:      2,
+					Arg2:      10,
+				},
+			},
+			want:    1024,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "power of negative base with fractional exponent",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task10",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_POWER,
+					Arg1:      -8,
+					Arg2:      0.5,
+				},
+			},
+			wantNaN: true,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "zero to negative power",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task11",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_POWER,
+					Arg1:      0,
+					Arg2:      -1,
+				},
+			},
+			wantNaN: true,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "modulo operation",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task12",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_MODULO,
+					Arg1:      -7,
+					Arg2:      3,
+				},
+			},
+			want:    2,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "modulo by zero",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task13",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_MODULO,
+					Arg1:      7,
+					Arg2:      0,
+				},
+			},
+			wantNaN: true,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "integer division operation",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task14",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_INTEGER_DIVISION,
+					Arg1:      -7,
+					Arg2:      2,
+				},
+			},
+			want:    -4,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "integer division by zero",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task15",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_INTEGER_DIVISION,
+					Arg1:      7,
+					Arg2:      0,
+				},
+			},
+			wantNaN: true,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "sqrt function",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task16",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_SQRT,
+					Args:      []float64{16},
+				},
+			},
+			want:    4,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "sqrt of negative",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task17",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_SQRT,
+					Args:      []float64{-4},
+				},
+			},
+			wantNaN: true,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "abs function",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task18",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_ABS,
+					Args:      []float64{-2.5},
+				},
+			},
+			want:    2.5,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "min function",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task19",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_MIN,
+					Args:      []float64{3, -1, 2},
+				},
+			},
+			want:    -1,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "max function",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task20",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_MAX,
+					Args:      []float64{3, -1, 2},
+				},
+			},
+			want:    3,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "round function",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task21",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_ROUND,
+					Args:      []float64{-2.5},
+				},
+			},
+			want:    -3,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "natural log function",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task22",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_LOG,
+					Args:      []float64{1},
+				},
+			},
+			want:    0,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "log function with base",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task23",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_LOG,
+					Args:      []float64{8, 2},
+				},
+			},
+			want:    3,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "log of zero",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task24",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_LOG,
+					Args:      []float64{0},
+				},
+			},
+			wantNaN: true,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "less comparison",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task26",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_LESS,
+					Arg1:      2,
+					Arg2:      3,
+				},
+			},
+			want:    1,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "greater or equal comparison",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task27",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_GREATER_OR_EQUAL,
+					Arg1:      2,
+					Arg2:      3,
+				},
+			},
+			want:    0,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "not equal comparison",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task28",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_NOT_EQUAL,
+					Arg1:      2,
+					Arg2:      3,
+				},
+			},
+			want:    1,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "logical and",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task29",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_AND,
+					Arg1:      5,
+					Arg2:      0,
+				},
+			},
+			want:    0,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "logical or",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task30",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_OR,
+					Arg1:      0,
+					Arg2:      -2,
+				},
+			},
+			want:    1,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "logical not",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task31",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_NOT,
+					Arg1:      0,
+				},
+			},
+			want:    1,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "if takes the else branch",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task32",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_IF,
+					Args:      []float64{0, 0, 7},
+				},
+			},
+			want:    7,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "function without arguments",
+			args: args{
+				ctx: context.Background(),
+				task: &calculatorv1.Task{
+					Id:        "task25",
+					Operation: calculatorv1.TaskOperation_TASK_OPERATION_MAX,
+					Args:      nil,
+				},
+			},
+			wantNaN: true,
+			wantErr: assert.NoError,
+		},
 		{
 			name: "unknown operation",
 			args: args{
@@ -160,26 +497,26 @@ func TestAgent_fetchTask(t *testing.T) {
 		name       string
 		setupMocks func(client *mocks.MockCalculatorAgentAPIClient)
 		args       args
-		want       *calculatorv1.Task
+		want       *calculatorv1.GetTaskResponse
 		wantErr    assert.ErrorAssertionFunc
 	}{
 		{
 			name: "successful fetch",
 			setupMocks: func(c *mocks.MockCalculatorAgentAPIClient) {
-				c.EXPECT().GetTask(mock.Anything).Return(&calculatorv1.Task{
+				c.EXPECT().GetTask(mock.Anything).Return(&calculatorv1.GetTaskResponse{Task: &calculatorv1.Task{
 					Id:        "task1",
 					Arg1:      10,
 					Arg2:      5,
 					Operation: calculatorv1.TaskOperation_TASK_OPERATION_ADDITION,
-				}, nil).Once()
+				}}, nil).Once()
 			},
 			args: args{ctx: context.Background()},
-			want: &calculatorv1.Task{
+			want: &calculatorv1.GetTaskResponse{Task: &calculatorv1.Task{
 				Id:        "task1",
 				Arg1:      10,
 				Arg2:      5,
 				Operation: calculatorv1.TaskOperation_TASK_OPERATION_ADDITION,
-			},
+			}},
 			wantErr: assert.NoError,
 		},
 		{
@@ -201,40 +538,40 @@ func TestAgent_fetchTask(t *testing.T) {
 			name: "retry once then succeed",
 			setupMocks: func(client *mocks.MockCalculatorAgentAPIClient) {
 				client.EXPECT().GetTask(mock.Anything).Return(nil, assert.AnError).Once()
-				client.EXPECT().GetTask(mock.Anything).Return(&calculatorv1.Task{
+				client.EXPECT().GetTask(mock.Anything).Return(&calculatorv1.GetTaskResponse{Task: &calculatorv1.Task{
 					Id:        "task2",
 					Arg1:      7,
 					Arg2:      8,
 					Operation: calculatorv1.TaskOperation_TASK_OPERATION_MULTIPLICATION,
-				}, nil).Once()
+				}}, nil).Once()
 			},
 			args: args{ctx: context.Background()},
-			want: &calculatorv1.Task{
+			want: &calculatorv1.GetTaskResponse{Task: &calculatorv1.Task{
 				Id:        "task2",
 				Arg1:      7,
 				Arg2:      8,
 				Operation: calculatorv1.TaskOperation_TASK_OPERATION_MULTIPLICATION,
-			},
+			}},
 			wantErr: assert.NoError,
 		},
 		{
 			name: "no tasks available then succeed",
 			setupMocks: func(c *mocks.MockCalculatorAgentAPIClient) {
 				c.EXPECT().GetTask(mock.Anything).Return(nil, client.ErrNoTasks).Once()
-				c.EXPECT().GetTask(mock.Anything).Return(&calculatorv1.Task{
+				c.EXPECT().GetTask(mock.Anything).Return(&calculatorv1.GetTaskResponse{Task: &calculatorv1.Task{
 					Id:        "task3",
 					Arg1:      20,
 					Arg2:      4,
 					Operation: calculatorv1.TaskOperation_TASK_OPERATION_DIVISION,
-				}, nil).Once()
+				}}, nil).Once()
 			},
 			args: args{ctx: context.Background()},
-			want: &calculatorv1.Task{
+			want: &calculatorv1.GetTaskResponse{Task: &calculatorv1.Task{
 				Id:        "task3",
 				Arg1:      20,
 				Arg2:      4,
 				Operation: calculatorv1.TaskOperation_TASK_OPERATION_DIVISION,
-			},
+			}},
 			wantErr: assert.NoError,
 		},
 	}
@@ -257,9 +594,8 @@ func TestAgent_fetchTask(t *testing.T) {
 
 func TestAgent_submitTaskResult(t *testing.T) {
 	type args struct {
-		ctx    context.Context
-		taskID string
-		result float64
+		ctx context.Context
+		req *calculatorv1.SubmitTaskResultRequest
 	}
 	tests := []struct {
 		name       string
@@ -276,9 +612,8 @@ func TestAgent_submitTaskResult(t *testing.T) {
 				}).Return(nil).Once()
 			},
 			args: args{
-				ctx:    context.Background(),
-				taskID: "task1",
-				result: 15,
+				ctx: context.Background(),
+				req: &calculatorv1.SubmitTaskResultRequest{Id: "task1", Result: 15},
 			},
 			wantErr: assert.NoError,
 		},
@@ -293,8 +628,7 @@ func TestAgent_submitTaskResult(t *testing.T) {
 					cancel()
 					return ctx
 				}(),
-				taskID: "task2",
-				result: 25,
+				req: &calculatorv1.SubmitTaskResultRequest{Id: "task2", Result: 25},
 			},
 			wantErr: assert.Error,
 		},
@@ -309,12 +643,24 @@ func TestAgent_submitTaskResult(t *testing.T) {
 				client.EXPECT().SubmitTaskResult(mock.Anything, req).Return(nil).Once()
 			},
 			args: args{
-				ctx:    context.Background(),
-				taskID: "task3",
-				result: 42,
+				ctx: context.Background(),
+				req: &calculatorv1.SubmitTaskResultRequest{Id: "task3", Result: 42},
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "lease lost",
+			setupMocks: func(c *mocks.MockCalculatorAgentAPIClient) {
+				c.EXPECT().SubmitTaskResult(mock.Anything, mock.Anything).Return(client.ErrLeaseLost).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.SubmitTaskResultRequest{Id: "task5", Result: 1, LeaseToken: "lease0"},
+			},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, client.ErrLeaseLost, msgAndArgs...)
+			},
+		},
 		{
 			name: "submit result with NaN",
 			setupMocks: func(c *mocks.MockCalculatorAgentAPIClient) {
@@ -323,9 +669,8 @@ func TestAgent_submitTaskResult(t *testing.T) {
 				})).Return(nil).Once()
 			},
 			args: args{
-				ctx:    context.Background(),
-				taskID: "task4",
-				result: math.NaN(),
+				ctx: context.Background(),
+				req: &calculatorv1.SubmitTaskResultRequest{Id: "task4", Result: math.NaN()},
 			},
 			wantErr: assert.NoError,
 		},
@@ -340,9 +685,71 @@ func TestAgent_submitTaskResult(t *testing.T) {
 
 			tt.wantErr(
 				t,
-				agent.submitTaskResult(tt.args.ctx, log, tt.args.taskID, tt.args.result),
-				fmt.Sprintf("submitTaskResult(%v, %v, %v, %v)", tt.args.ctx, log, tt.args.taskID, tt.args.result),
+				agent.submitTaskResult(tt.args.ctx, log, tt.args.req),
+				fmt.Sprintf("submitTaskResult(%v, %v, %v)", tt.args.ctx, log, tt.args.req),
 			)
 		})
 	}
 }
+
+func TestAgent_extendLease(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMocks   func(c *mocks.MockCalculatorAgentAPIClient)
+		lease        time.Duration
+		wantCanceled bool
+	}{
+		{
+			name: "lease extended while the task is executed",
+			setupMocks: func(c *mocks.MockCalculatorAgentAPIClient) {
+				c.EXPECT().ExtendTaskLease(mock.Anything, &calculatorv1.ExtendTaskLeaseRequest{Id: "task1", LeaseToken: "lease1"}).Return(nil)
+			},
+			lease:        30 * time.Millisecond,
+			wantCanceled: false,
+		},
+		{
+			name: "extension error retried",
+			setupMocks: func(c *mocks.MockCalculatorAgentAPIClient) {
+				c.EXPECT().ExtendTaskLease(mock.Anything, mock.Anything).Return(assert.AnError).Once()
+				c.EXPECT().ExtendTaskLease(mock.Anything, mock.Anything).Return(nil)
+			},
+			lease:        30 * time.Millisecond,
+			wantCanceled: false,
+		},
+		{
+			name: "lease lost",
+			setupMocks: func(c *mocks.MockCalculatorAgentAPIClient) {
+				c.EXPECT().ExtendTaskLease(mock.Anything, mock.Anything).Return(client.ErrLeaseLost).Once()
+			},
+			lease:        30 * time.Millisecond,
+			wantCanceled: true,
+		},
+		{
+			name:         "task not leased",
+			setupMocks:   func(c *mocks.MockCalculatorAgentAPIClient) {},
+			lease:        0,
+			wantCanceled: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := testutil.DiscardLogger()
+			mc := mocks.NewMockCalculatorAgentAPIClient(t)
+
+			tt.setupMocks(mc)
+			agent := New(&config.Config{}, log, mc)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			taskCtx, cancelTask := context.WithCancel(ctx)
+			defer cancelTask()
+
+			agent.extendLease(taskCtx, cancelTask, log, &calculatorv1.GetTaskResponse{
+				Task:          &calculatorv1.Task{Id: "task1"},
+				LeaseDuration: durationpb.New(tt.lease),
+				LeaseToken:    "lease1",
+			})
+			assert.Equal(t, tt.wantCanceled, ctx.Err() == nil && taskCtx.Err() != nil)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package agent
+
+import (
+	"context"
+	"math/big"
+
+	calculatorv1 "github.com/belo4ya/edu-dist-calculate-api/pkg/calculator/v1"
+)
+
+// maxBigIntBits limits the estimated size of powers in the big integer arithmetic.
+const maxBigIntBits = 1 << 20
+
+// executeBigIntTask performs the operation of the task over arbitrary-precision integer operands.
+// It returns nil if the operation is undefined for the operands, e.g. division by zero,
+// or its result isn't an integer, e.g. a negative power.
+func (a *Agent) executeBigIntTask(ctx context.Context, task *calculatorv1.Task) (*big.Int, error) {
+	if err := a.simulateWork(ctx, task); err != nil {
+		return nil, err
+	}
+
+	switch task.Operation {
+	case calculatorv1.TaskOperation_TASK_OPERATION_NEGATION:
+		x, ok := new(big.Int).SetString(task.ExactArg1, 10)
+		if !ok {
+			return nil, nil
+		}
+		return x.Neg(x), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_NOT:
+		x, ok := new(big.Int).SetString(task.ExactArg1, 10)
+		if !ok {
+			return nil, nil
+		}
+		return big.NewInt(int64(boolToFloat(x.Sign() == 0))), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_IF:
+		// Only the operand of the taken branch is set
+		if len(task.ExactArgs) != 3 {
+			return nil, nil
+		}
+		cond, ok := new(big.Int).SetString(task.ExactArgs[0], 10)
+		if !ok {
+			return nil, nil
+		}
+		branch := task.ExactArgs[2]
+		if cond.Sign() != 0 {
+			branch = task.ExactArgs[1]
+		}
+		res, ok := new(big.Int).SetString(branch, 10)
+		if !ok {
+			return nil, nil
+		}
+		return res, nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_ABS,
+		calculatorv1.TaskOperation_TASK_OPERATION_MIN,
+		calculatorv1.TaskOperation_TASK_OPERATION_MAX,
+		calculatorv1.TaskOperation_TASK_OPERATION_ROUND:
+		args := make([]*big.Int, len(task.ExactArgs))
+		for i, arg := range task.ExactArgs {
+			var ok bool
+			if args[i], ok = new(big.Int).SetString(arg, 10); !ok {
+				return nil, nil
+			}
+		}
+		return a.executeBigIntFunction(task.Operation, args), nil
+	}
+
+	x, ok1 := new(big.Int).SetString(task.ExactArg1, 10)
+	y, ok2 := new(big.Int).SetString(task.ExactArg2, 10)
+	if !ok1 || !ok2 {
+		return nil, nil
+	}
+	if res, ok := evaluateLogical(task.Operation, x.Cmp(y), x.Sign(), y.Sign()); ok {
+		return big.NewInt(int64(boolToFloat(res))), nil
+	}
+
+	switch task.Operation {
+	case calculatorv1.TaskOperation_TASK_OPERATION_ADDITION:
+		return new(big.Int).Add(x, y), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_SUBTRACTION:
+		return new(big.Int).Sub(x, y), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_MULTIPLICATION:
+		return new(big.Int).Mul(x, y), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_POWER:
+		return bigIntPow(x, y), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_MODULO:
+		if y.Sign() == 0 {
+			return nil, nil
+		}
+		_, m := bigIntFloorDivMod(x, y)
+		return m, nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_INTEGER_DIVISION:
+		if y.Sign() == 0 {
+			return nil, nil
+		}
+		q, _ := bigIntFloorDivMod(x, y)
+		return q, nil
+	default:
+		return nil, nil // division, sqrt and log results aren't integers in general
+	}
+}
+
+// executeBigIntFunction evaluates a function operation over integer arguments.
+// It returns nil for unsupported functions and a wrong number of arguments.
+func (a *Agent) executeBigIntFunction(op calculatorv1.TaskOperation, args []*big.Int) *big.Int {
+	if len(args) == 0 {
+		return nil
+	}
+
+	switch op {
+	case calculatorv1.TaskOperation_TASK_OPERATION_ABS:
+		if len(args) != 1 {
+			return nil
+		}
+		return new(big.Int).Abs(args[0])
+	case calculatorv1.TaskOperation_TASK_OPERATION_MIN:
+		res := args[0]
+		for _, arg := range args[1:] {
+			if arg.Cmp(res) < 0 {
+				res = arg
+			}
+		}
+		return res
+	case calculatorv1.TaskOperation_TASK_OPERATION_MAX:
+		res := args[0]
+		for _, arg := range args[1:] {
+			if arg.Cmp(res) > 0 {
+				res = arg
+			}
+		}
+		return res
+	case calculatorv1.TaskOperation_TASK_OPERATION_ROUND:
+		if len(args) != 1 {
+			return nil
+		}
+		return args[0] // integers are already rounded
+	default:
+		return nil
+	}
+}
+
+// bigIntFloorDivMod returns floor(x/y) and x - y*floor(x/y), which has the sign of the divisor, for y != 0.
+func bigIntFloorDivMod(x, y *big.Int) (*big.Int, *big.Int) {
+	q, m := new(big.Int).QuoRem(x, y, new(big.Int))
+	// Truncated division rounds towards zero, so the quotient is one too big if the signs differ
+	if m.Sign() != 0 && m.Sign() != y.Sign() {
+		q.Sub(q, big.NewInt(1))
+		m.Add(m, y)
+	}
+	return q, m
+}
+
+// bigIntPow returns x^y if y is non-negative and the result doesn't exceed maxBigIntBits, or nil otherwise.
+func bigIntPow(x, y *big.Int) *big.Int {
+	if y.Sign() < 0 {
+		return nil // a fraction unless x is 1 or -1
+	}
+	if x.CmpAbs(big.NewInt(1)) > 0 && (!y.IsInt64() || y.Int64() > maxBigIntBits/int64(x.BitLen()-1)) {
+		return nil
+	}
+	return new(big.Int).Exp(x, y, nil)
+}
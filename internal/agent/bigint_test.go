@@ -0,0 +1,178 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/belo4ya/edu-dist-calculate-api/internal/agent/config"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/testutil"
+	mocks "github.com/belo4ya/edu-dist-calculate-api/internal/testutil/mocks/agent"
+	calculatorv1 "github.com/belo4ya/edu-dist-calculate-api/pkg/calculator/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestAgent_executeBigIntTask(t *testing.T) {
+	binary := func(op calculatorv1.TaskOperation, x, y string) *calculatorv1.Task {
+		return &calculatorv1.Task{Operation: op, Arithmetic: calculatorv1.Arithmetic_ARITHMETIC_BIG_INT, ExactArg1: x, ExactArg2: y}
+	}
+	function := func(op calculatorv1.TaskOperation, args ...string) *calculatorv1.Task {
+		return &calculatorv1.Task{Operation: op, Arithmetic: calculatorv1.Arithmetic_ARITHMETIC_BIG_INT, ExactArgs: args}
+	}
+
+	type args struct {
+		ctx  context.Context
+		task *calculatorv1.Task
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string // "" if the result is undefined
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "addition beyond float64 precision",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_ADDITION, "9007199254740992", "1")},
+			want:    "9007199254740993",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "subtraction",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_SUBTRACTION, "1", "18446744073709551616")},
+			want:    "-18446744073709551615",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "multiplication",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_MULTIPLICATION, "18446744073709551616", "-18446744073709551616")},
+			want:    "-340282366920938463463374607431768211456",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "negation",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_NEGATION, "-12345678901234567890", "")},
+			want:    "12345678901234567890",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "power",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_POWER, "2", "127")},
+			want:    "170141183460469231731687303715884105728",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "power with negative exponent",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_POWER, "2", "-1")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "power of one with huge exponent",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_POWER, "-1", "100000000000000000001")},
+			want:    "-1",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "power with too big result",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_POWER, "3", "10000000")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "modulo has the sign of the divisor",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_MODULO, "7", "-2")},
+			want:    "-1",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "modulo by zero",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_MODULO, "7", "0")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "integer division rounds down",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_INTEGER_DIVISION, "-7", "2")},
+			want:    "-4",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "integer division without remainder",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_INTEGER_DIVISION, "-8", "2")},
+			want:    "-4",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "division isn't supported",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_DIVISION, "8", "2")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "abs",
+			args:    args{ctx: context.Background(), task: function(calculatorv1.TaskOperation_TASK_OPERATION_ABS, "-5")},
+			want:    "5",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "min and max",
+			args:    args{ctx: context.Background(), task: function(calculatorv1.TaskOperation_TASK_OPERATION_MIN, "9007199254740993", "9007199254740992")},
+			want:    "9007199254740992",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "equality beyond float64 precision",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_EQUAL, "9007199254740993", "9007199254740992")},
+			want:    "0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "if takes the else branch",
+			args:    args{ctx: context.Background(), task: function(calculatorv1.TaskOperation_TASK_OPERATION_IF, "0", "", "-3")},
+			want:    "-3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "fractional operand",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_ADDITION, "1/2", "1")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "context canceled",
+			args: args{
+				ctx: func() context.Context {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+					return ctx
+				}(),
+				task: func() *calculatorv1.Task {
+					task := binary(calculatorv1.TaskOperation_TASK_OPERATION_ADDITION, "1", "2")
+					task.OperationTime = durationpb.New(time.Second)
+					return task
+				}(),
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := mocks.NewMockCalculatorAgentAPIClient(t)
+
+			agent := New(&config.Config{}, testutil.DiscardLogger(), mc)
+
+			got, err := agent.executeBigIntTask(tt.args.ctx, tt.args.task)
+			if !tt.wantErr(t, err, fmt.Sprintf("executeBigIntTask(%v, %v)", tt.args.ctx, tt.args.task)) {
+				return
+			}
+			if tt.want == "" {
+				assert.Nil(t, got, "executeBigIntTask(%v, %v)", tt.args.ctx, tt.args.task)
+			} else if assert.NotNil(t, got, "executeBigIntTask(%v, %v)", tt.args.ctx, tt.args.task) {
+				assert.Equal(t, tt.want, got.String(), "executeBigIntTask(%v, %v)", tt.args.ctx, tt.args.task)
+			}
+		})
+	}
+}
@@ -18,7 +18,10 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-var ErrNoTasks = fmt.Errorf("no tasks")
+var (
+	ErrNoTasks   = fmt.Errorf("no tasks")
+	ErrLeaseLost = fmt.Errorf("lease lost")
+)
 
 type AgentAPI struct {
 	client calculatorv1.AgentServiceClient
@@ -53,7 +56,7 @@ func NewAgentAPI(ctx context.Context, conf *config.Config) (*AgentAPI, func(), e
 	return &AgentAPI{client: calculatorv1.NewAgentServiceClient(conn)}, cleanup, nil
 }
 
-func (c *AgentAPI) GetTask(ctx context.Context) (*calculatorv1.Task, error) {
+func (c *AgentAPI) GetTask(ctx context.Context) (*calculatorv1.GetTaskResponse, error) {
 	resp, err := c.client.GetTask(ctx, nil)
 	if err != nil {
 		grpcStatus := status.Convert(err)
@@ -62,12 +65,27 @@ func (c *AgentAPI) GetTask(ctx context.Context) (*calculatorv1.Task, error) {
 		}
 		return nil, fmt.Errorf("get task: %w", err)
 	}
-	return resp.GetTask(), nil
+	return resp, nil
+}
+
+func (c *AgentAPI) ExtendTaskLease(ctx context.Context, req *calculatorv1.ExtendTaskLeaseRequest) error {
+	_, err := c.client.ExtendTaskLease(ctx, req)
+	if err != nil {
+		grpcStatus := status.Convert(err)
+		if grpcStatus.Code() == codes.FailedPrecondition || grpcStatus.Code() == codes.NotFound {
+			return ErrLeaseLost
+		}
+		return fmt.Errorf("extend task lease: %w", err)
+	}
+	return nil
 }
 
 func (c *AgentAPI) SubmitTaskResult(ctx context.Context, res *calculatorv1.SubmitTaskResultRequest) error {
 	_, err := c.client.SubmitTaskResult(ctx, res)
 	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return ErrLeaseLost
+		}
 		return fmt.Errorf("submit task result: %w", err)
 	}
 	return nil
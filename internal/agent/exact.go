@@ -0,0 +1,178 @@
+package agent
+
+import (
+	"context"
+	"math/big"
+
+	calculatorv1 "github.com/belo4ya/edu-dist-calculate-api/pkg/calculator/v1"
+)
+
+// maxExactExponent limits exponents of powers in the exact arithmetic, as the size of the result grows linearly with it.
+const maxExactExponent = 4096
+
+// executeExactTask performs the operation of the task over exact rational operands.
+// It returns nil if the operation is undefined for the operands, e.g. division by zero,
+// or can't be calculated exactly, e.g. a fractional power.
+func (a *Agent) executeExactTask(ctx context.Context, task *calculatorv1.Task) (*big.Rat, error) {
+	if err := a.simulateWork(ctx, task); err != nil {
+		return nil, err
+	}
+
+	switch task.Operation {
+	case calculatorv1.TaskOperation_TASK_OPERATION_NEGATION:
+		x, ok := new(big.Rat).SetString(task.ExactArg1)
+		if !ok {
+			return nil, nil
+		}
+		return x.Neg(x), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_NOT:
+		x, ok := new(big.Rat).SetString(task.ExactArg1)
+		if !ok {
+			return nil, nil
+		}
+		return big.NewRat(int64(boolToFloat(x.Sign() == 0)), 1), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_IF:
+		// Only the operand of the taken branch is set
+		if len(task.ExactArgs) != 3 {
+			return nil, nil
+		}
+		cond, ok := new(big.Rat).SetString(task.ExactArgs[0])
+		if !ok {
+			return nil, nil
+		}
+		branch := task.ExactArgs[2]
+		if cond.Sign() != 0 {
+			branch = task.ExactArgs[1]
+		}
+		res, ok := new(big.Rat).SetString(branch)
+		if !ok {
+			return nil, nil
+		}
+		return res, nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_ABS,
+		calculatorv1.TaskOperation_TASK_OPERATION_MIN,
+		calculatorv1.TaskOperation_TASK_OPERATION_MAX,
+		calculatorv1.TaskOperation_TASK_OPERATION_ROUND:
+		args := make([]*big.Rat, len(task.ExactArgs))
+		for i, arg := range task.ExactArgs {
+			var ok bool
+			if args[i], ok = new(big.Rat).SetString(arg); !ok {
+				return nil, nil
+			}
+		}
+		return a.executeExactFunction(task.Operation, args), nil
+	}
+
+	x, ok1 := new(big.Rat).SetString(task.ExactArg1)
+	y, ok2 := new(big.Rat).SetString(task.ExactArg2)
+	if !ok1 || !ok2 {
+		return nil, nil
+	}
+	if res, ok := evaluateLogical(task.Operation, x.Cmp(y), x.Sign(), y.Sign()); ok {
+		return big.NewRat(int64(boolToFloat(res)), 1), nil
+	}
+
+	switch task.Operation {
+	case calculatorv1.TaskOperation_TASK_OPERATION_ADDITION:
+		return new(big.Rat).Add(x, y), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_SUBTRACTION:
+		return new(big.Rat).Sub(x, y), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_MULTIPLICATION:
+		return new(big.Rat).Mul(x, y), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_DIVISION:
+		if y.Sign() == 0 {
+			return nil, nil
+		}
+		return new(big.Rat).Quo(x, y), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_POWER:
+		return exactPow(x, y), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_MODULO:
+		if y.Sign() == 0 {
+			return nil, nil
+		}
+		// x - y*floor(x/y) has the sign of the divisor, consistent with the integer division
+		q := new(big.Rat).SetInt(exactFloorDiv(x, y))
+		return new(big.Rat).Sub(x, q.Mul(q, y)), nil
+	case calculatorv1.TaskOperation_TASK_OPERATION_INTEGER_DIVISION:
+		if y.Sign() == 0 {
+			return nil, nil
+		}
+		return new(big.Rat).SetInt(exactFloorDiv(x, y)), nil
+	default:
+		return nil, nil // sqrt and log results are irrational in general
+	}
+}
+
+// executeExactFunction evaluates a function operation over exact arguments.
+// It returns nil for unsupported functions and a wrong number of arguments.
+func (a *Agent) executeExactFunction(op calculatorv1.TaskOperation, args []*big.Rat) *big.Rat {
+	if len(args) == 0 {
+		return nil
+	}
+
+	switch op {
+	case calculatorv1.TaskOperation_TASK_OPERATION_ABS:
+		if len(args) != 1 {
+			return nil
+		}
+		return new(big.Rat).Abs(args[0])
+	case calculatorv1.TaskOperation_TASK_OPERATION_MIN:
+		res := args[0]
+		for _, arg := range args[1:] {
+			if arg.Cmp(res) < 0 {
+				res = arg
+			}
+		}
+		return res
+	case calculatorv1.TaskOperation_TASK_OPERATION_MAX:
+		res := args[0]
+		for _, arg := range args[1:] {
+			if arg.Cmp(res) > 0 {
+				res = arg
+			}
+		}
+		return res
+	case calculatorv1.TaskOperation_TASK_OPERATION_ROUND:
+		if len(args) != 1 {
+			return nil
+		}
+		// Half away from zero: floor(|x| + 1/2) with the sign of x
+		abs := new(big.Rat).Abs(args[0])
+		res := new(big.Rat).SetInt(exactFloorDiv(abs.Add(abs, big.NewRat(1, 2)), big.NewRat(1, 1)))
+		if args[0].Sign() < 0 {
+			res.Neg(res)
+		}
+		return res
+	default:
+		return nil
+	}
+}
+
+// exactFloorDiv returns floor(x/y) for y != 0.
+func exactFloorDiv(x, y *big.Rat) *big.Int {
+	q := new(big.Rat).Quo(x, y)
+	// Euclidean division rounds down, as the denominator is positive
+	return new(big.Int).Div(q.Num(), q.Denom())
+}
+
+// exactPow returns x^y if y is an integer not exceeding maxExactExponent by absolute value
+// and the estimated size of the result doesn't exceed maxBigIntBits, or nil otherwise.
+func exactPow(x, y *big.Rat) *big.Rat {
+	if !y.IsInt() || y.Num().CmpAbs(big.NewInt(maxExactExponent)) > 0 {
+		return nil
+	}
+	if x.Sign() == 0 && y.Sign() < 0 {
+		return nil // division by zero
+	}
+
+	exp := new(big.Int).Abs(y.Num())
+	if int64(x.Num().BitLen()+x.Denom().BitLen())*exp.Int64() > maxBigIntBits {
+		return nil // e.g. chained powers of big numbers
+	}
+	num := new(big.Int).Exp(x.Num(), exp, nil)
+	denom := new(big.Int).Exp(x.Denom(), exp, nil)
+	if y.Sign() < 0 {
+		num, denom = denom, num
+	}
+	return new(big.Rat).SetFrac(num, denom)
+}
@@ -0,0 +1,210 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/belo4ya/edu-dist-calculate-api/internal/agent/config"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/testutil"
+	mocks "github.com/belo4ya/edu-dist-calculate-api/internal/testutil/mocks/agent"
+	calculatorv1 "github.com/belo4ya/edu-dist-calculate-api/pkg/calculator/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestAgent_executeExactTask(t *testing.T) {
+	binary := func(op calculatorv1.TaskOperation, x, y string) *calculatorv1.Task {
+		return &calculatorv1.Task{Operation: op, Arithmetic: calculatorv1.Arithmetic_ARITHMETIC_EXACT, ExactArg1: x, ExactArg2: y}
+	}
+	function := func(op calculatorv1.TaskOperation, args ...string) *calculatorv1.Task {
+		return &calculatorv1.Task{Operation: op, Arithmetic: calculatorv1.Arithmetic_ARITHMETIC_EXACT, ExactArgs: args}
+	}
+
+	type args struct {
+		ctx  context.Context
+		task *calculatorv1.Task
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string // "" if the result is undefined
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "addition of decimals is exact",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_ADDITION, "1/10", "1/5")},
+			want:    "3/10",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "subtraction",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_SUBTRACTION, "1", "1/3")},
+			want:    "2/3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "multiplication of big numbers",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_MULTIPLICATION, "18446744073709551616", "18446744073709551616")},
+			want:    "340282366920938463463374607431768211456",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "division",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_DIVISION, "1", "3")},
+			want:    "1/3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "division by zero",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_DIVISION, "1", "0")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "negation",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_NEGATION, "1/3", "")},
+			want:    "-1/3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "power with negative exponent",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_POWER, "-2/3", "-3")},
+			want:    "-27/8",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "power with fractional exponent",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_POWER, "4", "1/2")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "power with too big exponent",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_POWER, "2", "1000000")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "power with too big result",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_POWER, "1"+strings.Repeat("0", 4096), "4096")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "power of fraction with too big result",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_POWER, "1/"+strings.Repeat("9", 400), "-4096")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "power with big result within the limit",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_POWER, "-1/2", "4096")},
+			want:    "1/" + new(big.Int).Lsh(big.NewInt(1), 4096).String(),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "zero to negative power",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_POWER, "0", "-1")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "modulo has the sign of the divisor",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_MODULO, "-7", "2")},
+			want:    "1",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "modulo of fractions",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_MODULO, "7/2", "-1")},
+			want:    "-1/2",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "integer division rounds down",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_INTEGER_DIVISION, "-7", "2")},
+			want:    "-4",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "abs",
+			args:    args{ctx: context.Background(), task: function(calculatorv1.TaskOperation_TASK_OPERATION_ABS, "-1/3")},
+			want:    "1/3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "min and max",
+			args:    args{ctx: context.Background(), task: function(calculatorv1.TaskOperation_TASK_OPERATION_MAX, "1/3", "333/1000", "-1")},
+			want:    "1/3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "round half away from zero",
+			args:    args{ctx: context.Background(), task: function(calculatorv1.TaskOperation_TASK_OPERATION_ROUND, "-5/2")},
+			want:    "-3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "comparison of fractions",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_LESS, "1/3", "333/1000")},
+			want:    "0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "if takes the then branch",
+			args:    args{ctx: context.Background(), task: function(calculatorv1.TaskOperation_TASK_OPERATION_IF, "1", "1/3", "")},
+			want:    "1/3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "sqrt is not exact",
+			args:    args{ctx: context.Background(), task: function(calculatorv1.TaskOperation_TASK_OPERATION_SQRT, "4")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "malformed operand",
+			args:    args{ctx: context.Background(), task: binary(calculatorv1.TaskOperation_TASK_OPERATION_ADDITION, "0.1.2", "1")},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "context canceled",
+			args: args{
+				ctx: func() context.Context {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+					return ctx
+				}(),
+				task: func() *calculatorv1.Task {
+					task := binary(calculatorv1.TaskOperation_TASK_OPERATION_ADDITION, "1", "2")
+					task.OperationTime = durationpb.New(time.Second)
+					return task
+				}(),
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := mocks.NewMockCalculatorAgentAPIClient(t)
+
+			agent := New(&config.Config{}, testutil.DiscardLogger(), mc)
+
+			got, err := agent.executeExactTask(tt.args.ctx, tt.args.task)
+			if !tt.wantErr(t, err, fmt.Sprintf("executeExactTask(%v, %v)", tt.args.ctx, tt.args.task)) {
+				return
+			}
+			if tt.want == "" {
+				assert.Nil(t, got, "executeExactTask(%v, %v)", tt.args.ctx, tt.args.task)
+			} else if assert.NotNil(t, got, "executeExactTask(%v, %v)", tt.args.ctx, tt.args.task) {
+				assert.Equal(t, tt.want, got.RatString(), "executeExactTask(%v, %v)", tt.args.ctx, tt.args.task)
+			}
+		})
+	}
+}
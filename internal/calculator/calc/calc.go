@@ -1,15 +1,85 @@
 package calc
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"math/big"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/stackx"
 	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
 	"github.com/rs/xid"
 )
 
+// functions maps names of supported functions to the allowed number of arguments (-1 for unlimited).
+var functions = map[string]struct{ minArgs, maxArgs int }{
+	"sqrt":  {minArgs: 1, maxArgs: 1},
+	"abs":   {minArgs: 1, maxArgs: 1},
+	"round": {minArgs: 1, maxArgs: 1},
+	"log":   {minArgs: 1, maxArgs: 2}, // log(x) is natural, log(x, base) is for an arbitrary base
+	"min":   {minArgs: 1, maxArgs: -1},
+	"max":   {minArgs: 1, maxArgs: -1},
+	"if":    {minArgs: 3, maxArgs: 3}, // if(condition, then, else) calculates only the taken branch
+}
+
+// constants maps names of predefined constants to their values.
+// Variables passed to Parse take precedence over them.
+var constants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// symbols is the whitelist of operators and punctuation, mapping their spelling to the token.
+// Any other character, except digits, letters and whitespaces, is rejected by the tokenizer.
+var symbols = map[string]string{
+	"+":  "+",
+	"-":  "-",
+	"*":  "*",
+	"/":  "/",
+	"%":  "%",
+	"^":  "^",
+	"**": "^",
+	"//": "//",
+	"<":  "<",
+	"<=": "<=",
+	">":  ">",
+	">=": ">=",
+	"==": "==",
+	"!=": "!=",
+	"&&": "&&",
+	"||": "||",
+	"!":  "not",
+	"(":  "(",
+	")":  ")",
+	"[":  "[",
+	"]":  "]",
+	",":  ",",
+}
+
+// glyphs maps alternative spellings of operators, e.g. from formulas pasted from documents, to the ASCII ones.
+var glyphs = map[string]string{
+	"×": "*",
+	"·": "*",
+	"÷": "/",
+	"−": "-", // minus sign
+}
+
+// brackets maps closing brackets to the opening ones. Function calls only use parentheses,
+// square brackets are an alternate grouping, e.g. "[1 + 2] * 3".
+var brackets = map[string]string{
+	")": "(",
+	"]": "[",
+}
+
+// errOutOfRange is returned by scanNumber for well-formed literals exceeding the float64 range,
+// which are allowed only in the arbitrary-precision arithmetic.
+var errOutOfRange = errors.New("out of range")
+
 // Calculator handles expression parsing and scheduling for mathematical operations.
 type Calculator struct{}
 
@@ -19,163 +89,722 @@ func NewCalculator() *Calculator {
 }
 
 // Parse converts a string expression into a sequence of tokens in Reverse Polish Notation (RPN).
-// Returns types.ErrInvalidExpr if the expression is invalid or cannot be parsed.
-func (c *Calculator) Parse(s string) ([]types.Token, error) {
-	tokens, err := c.tokenize(s)
+// Identifiers other than function names are substituted with values of vars or predefined constants,
+// the resulting number tokens keep the identifier in types.Token.Name.
+// Calls of the user-defined funcs are expanded inline, so the tokens contain only built-in operations.
+// Returns *types.ParseError (wrapping types.ErrInvalidExpr) if the expression is invalid or cannot be parsed,
+// and an error wrapping types.ErrInvalidVariable if vars have non-finite values or names that can't be referenced.
+func (c *Calculator) Parse(s string, vars map[string]float64, funcs map[string]types.Function) ([]types.Token, error) {
+	node, err := c.ParseAST(s, vars, funcs)
+	if err != nil {
+		return nil, err
+	}
+	return c.toRPN(node), nil
+}
+
+// ParseInt is Parse for the arbitrary-precision integer arithmetic: number literals, constants and variables
+// must have integer values, e.g. "1e3" and "0xFF" are accepted, while "0.5" and "pi" are rejected
+// with types.ReasonInvalidNumber.
+// Literals beyond the float64 range are accepted, their approximations in types.Token.Number are saturated.
+func (c *Calculator) ParseInt(s string, vars map[string]float64, funcs map[string]types.Function) ([]types.Token, error) {
+	node, err := c.parseLiterals(s, vars, funcs, integerLiterals)
+	if err != nil {
+		return nil, err
+	}
+	return c.toRPN(node), nil
+}
+
+// ParseExact is Parse for the exact rational arithmetic: the irrational constants "pi" and "e" are rejected
+// with types.ReasonInvalidNumber unless vars define them, as their values can't be represented exactly.
+// Literals beyond the float64 range are accepted, their approximations in types.Token.Number are saturated.
+func (c *Calculator) ParseExact(s string, vars map[string]float64, funcs map[string]types.Function) ([]types.Token, error) {
+	node, err := c.parseLiterals(s, vars, funcs, exactLiterals)
+	if err != nil {
+		return nil, err
+	}
+	return c.toRPN(node), nil
+}
+
+// ParseAST converts a string expression into its abstract syntax tree.
+// Identifiers other than function names are substituted with values of vars or predefined constants,
+// calls of the user-defined funcs are expanded inline.
+// Returns *types.ParseError (wrapping types.ErrInvalidExpr) if the expression is invalid or cannot be parsed,
+// and an error wrapping types.ErrInvalidVariable if vars are invalid, see Parse.
+func (c *Calculator) ParseAST(s string, vars map[string]float64, funcs map[string]types.Function) (types.Node, error) {
+	return c.parseLiterals(s, vars, funcs, floatLiterals)
+}
+
+// parseLiterals converts a string expression into its abstract syntax tree, checking the numbers it contains
+// against the literals of the arithmetic.
+func (c *Calculator) parseLiterals(
+	s string,
+	vars map[string]float64,
+	funcs map[string]types.Function,
+	literals literals,
+) (types.Node, error) {
+	if err := c.checkVars(vars); err != nil {
+		return nil, err
+	}
+	tokens, err := c.tokenize(s, vars, funcs)
 	if err != nil {
 		return nil, fmt.Errorf("tokenize: %w", err)
 	}
-	rpn, err := c.toRPN(tokens)
+	if err := c.checkLiterals(tokens, literals); err != nil {
+		return nil, fmt.Errorf("tokenize: %w", err)
+	}
+	node, err := c.parse(tokens, funcs, literals)
 	if err != nil {
-		return nil, fmt.Errorf("to RPN: %w", err)
+		return nil, fmt.Errorf("parse: %w", err)
 	}
-	return rpn, nil
+	return node, nil
+}
+
+// checkVars checks that the vars can be referenced in expressions and have finite values,
+// as built-in functions would shadow them and non-finite values can't be calculated.
+func (c *Calculator) checkVars(vars map[string]float64) error {
+	for _, name := range slices.Sorted(maps.Keys(vars)) {
+		switch val := vars[name]; {
+		case !identifier.MatchString(name):
+			return fmt.Errorf("%w: %q isn't an identifier", types.ErrInvalidVariable, name)
+		case c.isFunc(name):
+			return fmt.Errorf("%w: %q is a built-in function", types.ErrInvalidVariable, name)
+		case math.IsNaN(val) || math.IsInf(val, 0):
+			return fmt.Errorf("%w: %q must be finite, got %v", types.ErrInvalidVariable, name, val)
+		}
+	}
+	return nil
 }
 
 // Schedule transforms RPN tokens into a sequence of executable tasks.
 // Each task represents an operation that depends on either values or results of other tasks.
-func (c *Calculator) Schedule(rpn []types.Token) []types.Task {
-	plan := make([]types.Task, 0, len(rpn))
+// If no operation has to be executed, the plan has no tasks and carries the value of the expression.
+func (c *Calculator) Schedule(rpn []types.Token) types.Plan {
+	return c.ScheduleAST(c.fromRPN(rpn))
+}
 
-	type stackItem struct {
-		IsTask bool
-		TaskID string
-		Value  float64
+// ScheduleAST transforms the abstract syntax tree into a sequence of executable tasks,
+// where every task follows the tasks it depends on.
+// Identical subtrees, e.g. "a+b" in "(a+b)*(a+b)", are calculated by a single task shared by all dependent tasks.
+// If no operation has to be executed, the plan has no tasks and carries the value of the expression.
+func (c *Calculator) ScheduleAST(node types.Node) types.Plan {
+	s := &scheduler{plan: make([]types.Task, 0), scheduled: map[string]string{}}
+	if res := s.schedule(node); !res.IsTask {
+		return types.Plan{Tasks: s.plan, Value: res.Value, Exact: res.Exact}
 	}
-	stack := stackx.New[stackItem]()
+	return types.Plan{Tasks: s.plan}
+}
 
-	for _, token := range rpn {
-		if token.IsNumber {
-			stack.Push(stackItem{IsTask: false, Value: token.Number})
-			continue
-		}
+// scheduler collects tasks while walking the AST.
+type scheduler struct {
+	plan []types.Task
+
+	// scheduled maps signatures of the scheduled tasks to their IDs
+	scheduled map[string]string
 
-		task := types.Task{ID: xid.New().String(), Operation: token.Symbol}
+	// condition is the innermost branch of "if" being scheduled, every task added is conditional on it
+	condition condition
+}
+
+// condition is a branch of "if", see types.Task.ConditionTaskID.
+type condition struct {
+	TaskID string
+	Value  bool
+}
+
+// operand is either a value known in advance or a result of a task.
+type operand struct {
+	IsTask bool
+	TaskID string
+	Value  float64
+	Exact  string
+}
 
-		right, left := stack.SafePop(), stack.SafePop()
-		if left.IsTask {
-			task.ParentTask1ID = left.TaskID
-		} else {
-			task.Arg1 = left.Value
+// schedule appends tasks calculating the node to the plan and returns the operand holding its result.
+func (s *scheduler) schedule(node types.Node) operand {
+	switch n := node.(type) {
+	case *types.NumberNode:
+		return operand{Value: n.Value, Exact: n.Exact}
+	case *types.UnaryNode:
+		arg := s.schedule(n.Operand)
+		// Negation of a literal is folded right away, otherwise it becomes a unary task
+		if n.Op == "neg" && !arg.IsTask {
+			return operand{Value: -arg.Value, Exact: negateExact(arg.Exact)}
 		}
-		if right.IsTask {
-			task.ParentTask2ID = right.TaskID
-		} else {
-			task.Arg2 = right.Value
+		return s.add(types.Task{Operation: n.Op, ParentTask1ID: arg.TaskID, Arg1: arg.Value, ExactArg1: arg.Exact})
+	case *types.BinaryNode:
+		left, right := s.schedule(n.Left), s.schedule(n.Right)
+		return s.add(types.Task{
+			Operation:     n.Op,
+			ParentTask1ID: left.TaskID,
+			ParentTask2ID: right.TaskID,
+			Arg1:          left.Value,
+			Arg2:          right.Value,
+			ExactArg1:     left.Exact,
+			ExactArg2:     right.Exact,
+		})
+	case *types.CallNode:
+		if n.Func == "if" {
+			return s.scheduleIf(n)
 		}
+		task := types.Task{
+			Operation:     n.Func,
+			ParentTaskIDs: make([]string, len(n.Args)),
+			Args:          make([]float64, len(n.Args)),
+			ExactArgs:     make([]string, len(n.Args)),
+		}
+		for i, argNode := range n.Args {
+			arg := s.schedule(argNode)
+			task.ParentTaskIDs[i], task.Args[i], task.ExactArgs[i] = arg.TaskID, arg.Value, arg.Exact
+		}
+		return s.add(task)
+	default:
+		return operand{}
+	}
+}
+
+// scheduleIf schedules the condition and both branches of "if", the tasks of each branch are conditional
+// on the result of the condition, so only one of them is executed. If the condition is known in advance,
+// only the taken branch is scheduled.
+func (s *scheduler) scheduleIf(n *types.CallNode) operand {
+	cond := s.schedule(n.Args[0])
+	if !cond.IsTask {
+		if cond.isTrue() {
+			return s.schedule(n.Args[1])
+		}
+		return s.schedule(n.Args[2])
+	}
 
-		plan = append(plan, task)
-		stack.Push(stackItem{IsTask: true, TaskID: task.ID})
+	task := types.Task{
+		Operation:     n.Func,
+		ParentTaskIDs: []string{cond.TaskID, "", ""},
+		Args:          make([]float64, 3),
+		ExactArgs:     make([]string, 3),
+	}
+	outer := s.condition
+	for i, value := range []bool{true, false} {
+		s.condition = condition{TaskID: cond.TaskID, Value: value}
+		arg := s.schedule(n.Args[i+1])
+		task.ParentTaskIDs[i+1], task.Args[i+1], task.ExactArgs[i+1] = arg.TaskID, arg.Value, arg.Exact
 	}
+	s.condition = outer
+	return s.add(task)
+}
 
-	return plan
+// isTrue reports whether the value is true as a condition, that is, non-zero.
+func (o operand) isTrue() bool {
+	if o.Exact != "" {
+		return o.Exact != "0"
+	}
+	return o.Value != 0
 }
 
-// tokenize breaks an input string into individual tokens (numbers and operators).
-// Returns types.ErrInvalidExpr if the expression contains invalid numeric values.
-func (c *Calculator) tokenize(s string) ([]types.Token, error) {
-	tokens := make([]types.Token, 0, len(s))
-	var numberBuf strings.Builder
-	for _, ch := range strings.Split(s, "") {
-		if ch >= "0" && ch <= "9" || ch == "." {
-			numberBuf.WriteString(ch)
-		} else if ch != " " {
-			if numberBuf.Len() > 0 {
-				num, err := strconv.ParseFloat(numberBuf.String(), 64)
-				if err != nil {
-					return nil, types.ErrInvalidExpr
-				}
-				tokens = append(tokens, types.NewToken(num))
-				numberBuf.Reset()
-			}
-			tokens = append(tokens, types.NewToken(ch))
+// add appends the task to the plan, unless the same operation over the same operands is already scheduled.
+func (s *scheduler) add(task types.Task) operand {
+	sig := s.signature(task)
+	if id, ok := s.scheduled[sig]; ok {
+		return operand{IsTask: true, TaskID: id}
+	}
+
+	task.ID = xid.New().String()
+	task.ConditionTaskID, task.ConditionValue = s.condition.TaskID, s.condition.Value
+	s.plan = append(s.plan, task)
+	s.scheduled[sig] = task.ID
+	return operand{IsTask: true, TaskID: task.ID}
+}
+
+// signature identifies the result of the task by its operation and operands.
+// Operands are results of parent tasks, which are deduplicated first, so identical subtrees have equal signatures.
+// Tasks are shared only within the same branch of "if", so a task of the untaken branch is never needed.
+func (s *scheduler) signature(task types.Task) string {
+	var sb strings.Builder
+	if s.condition.TaskID != "" {
+		sb.WriteString(s.condition.TaskID + "=" + strconv.FormatBool(s.condition.Value) + " ")
+	}
+	sb.WriteString(task.Operation)
+	writeOperand := func(parentID string, arg float64, exact string) {
+		sb.WriteByte(' ')
+		switch {
+		case parentID != "":
+			sb.WriteString(parentID)
+		case exact != "":
+			sb.WriteString(exact) // literals with the same float64 value may differ exactly
+		default:
+			sb.WriteString(strconv.FormatFloat(arg, 'g', -1, 64))
 		}
 	}
-	if numberBuf.Len() > 0 {
-		num, err := strconv.ParseFloat(numberBuf.String(), 64)
-		if err != nil {
-			return nil, types.ErrInvalidExpr
+	if task.ParentTaskIDs != nil {
+		for i, parentID := range task.ParentTaskIDs {
+			writeOperand(parentID, task.Args[i], task.ExactArgs[i])
 		}
-		tokens = append(tokens, types.NewToken(num))
+	} else {
+		writeOperand(task.ParentTask1ID, task.Arg1, task.ExactArg1)
+		writeOperand(task.ParentTask2ID, task.Arg2, task.ExactArg2)
 	}
-	return tokens, nil
+	return sb.String()
 }
 
-// toRPN converts a sequence of tokens to Reverse Polish Notation using the shunting-yard algorithm.
-// Returns types.ErrInvalidExpr if the resulting RPN expression is invalid.
-func (c *Calculator) toRPN(tokens []types.Token) ([]types.Token, error) {
-	rpn := make([]types.Token, 0, len(tokens))
-	stack := stackx.New[types.Token]()
-	for _, t := range tokens {
+// toRPN converts the AST into a sequence of tokens in Reverse Polish Notation.
+func (c *Calculator) toRPN(node types.Node) []types.Token {
+	var rpn []types.Token
+	var walk func(types.Node)
+	walk = func(node types.Node) {
+		switch n := node.(type) {
+		case *types.NumberNode:
+			rpn = append(rpn, types.Token{IsNumber: true, Number: n.Value, Name: n.Name, Exact: n.Exact})
+		case *types.UnaryNode:
+			walk(n.Operand)
+			rpn = append(rpn, types.NewToken(n.Op))
+		case *types.BinaryNode:
+			walk(n.Left)
+			walk(n.Right)
+			rpn = append(rpn, types.NewToken(n.Op))
+		case *types.CallNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+			rpn = append(rpn, types.NewFuncToken(n.Func, len(n.Args)))
+		}
+	}
+	walk(node)
+	return rpn
+}
+
+// fromRPN restores the AST from a sequence of tokens in Reverse Polish Notation.
+func (c *Calculator) fromRPN(rpn []types.Token) types.Node {
+	stack := stackx.New[types.Node]()
+	for _, t := range rpn {
 		switch {
 		case t.IsNumber:
-			rpn = append(rpn, t)
-		case t.Symbol == "(":
-			stack.Push(t)
-		case t.Symbol == ")":
-			for stack.Size() > 0 && stack.SafePeek().Symbol != "(" {
-				rpn = append(rpn, stack.SafePop())
+			stack.Push(&types.NumberNode{Value: t.Number, Name: t.Name, Exact: t.Exact})
+		case c.isUnaryOp(t.Symbol):
+			stack.Push(&types.UnaryNode{Op: t.Symbol, Operand: stack.SafePop()})
+		case c.isFunc(t.Symbol):
+			call := &types.CallNode{Func: t.Symbol, Args: make([]types.Node, t.Arity)}
+			for i := t.Arity - 1; i >= 0; i-- {
+				call.Args[i] = stack.SafePop()
+			}
+			stack.Push(call)
+		default:
+			right, left := stack.SafePop(), stack.SafePop()
+			stack.Push(&types.BinaryNode{Op: t.Symbol, Left: left, Right: right})
+		}
+	}
+	return stack.SafePop()
+}
+
+// lexeme is a token along with its location in the expression.
+type lexeme struct {
+	types.Token
+	Offset int    // byte offset of the token in the expression
+	Text   string // source text of the token
+
+	// OutOfRange reports that the literal exceeds the float64 range, Number is saturated then
+	OutOfRange bool
+	// Irrational reports that the token is a predefined constant, Number is its approximation then
+	Irrational bool
+}
+
+// literals is the set of numbers allowed in an expression, depending on the arithmetic it is calculated in.
+type literals int
+
+const (
+	floatLiterals   literals = iota // numbers within the float64 range
+	integerLiterals                 // integers of any size, see Calculator.ParseInt
+	exactLiterals                   // rational numbers of any size, see Calculator.ParseExact
+)
+
+// tokenize breaks an input string into individual tokens (numbers, operators and function names).
+// Identifiers that aren't built-in function names are resolved to numbers using vars,
+// then to the user-defined funcs and then to constants.
+// A prefix "-" becomes a "neg" token, a prefix "+" becomes a "pos" token, which is dropped by the parser
+// as it doesn't change the value.
+// "!" becomes a "not" token, which is only valid as a prefix.
+// Operators and punctuation are accepted only if they are listed in symbols, or their glyphs are.
+// Multiplication is implicit between a number literal or a closing bracket and a following bracket
+// or identifier, e.g. "2(3+4)", "3x" and "(1+2)(3+4)". Note that it has the same precedence as "*", so "6/2x" is "6/2*x".
+// "e" after a number starts an exponent only if digits follow, so "2e" is "2*e" and "2e+1" is 20, while "2e + 1"
+// is "2*e + 1" and "2e+x" is "2*e + x". A signed exponent is rejected as ambiguous if its letter is bound by vars
+// or used as a value elsewhere in the expression, e.g. "2e+1" along with "e" in "2e+1 - e".
+// Returns *types.ParseError if the expression contains unknown characters or identifiers or malformed numbers.
+func (c *Calculator) tokenize(s string, vars map[string]float64, funcs map[string]types.Function) ([]lexeme, error) {
+	tokens := make([]lexeme, 0, len(s))
+
+	// chars are the runes of s with glyphs replaced, offsets[i] is the byte offset of chars[i] in s
+	chars := make([]string, 0, len(s))
+	offsets := make([]int, 1, len(s)+1)
+	for offset := 0; offset < len(s); {
+		_, size := utf8.DecodeRuneInString(s[offset:])
+		ch := s[offset : offset+size]
+		if glyph, ok := glyphs[ch]; ok {
+			ch = glyph
+		}
+		chars = append(chars, ch)
+		offset += size
+		offsets = append(offsets, offset)
+	}
+	emit := func(t types.Token, start, end int) {
+		tokens = append(tokens, lexeme{Token: t, Offset: offsets[start], Text: s[offsets[start]:offsets[end]]})
+	}
+	// implicitMul inserts the omitted "*" before the operand starting at chars[start]
+	implicitMul := func(start int) {
+		if len(tokens) == 0 {
+			return
+		}
+		if prev := tokens[len(tokens)-1]; prev.IsNumber && prev.Name == "" || c.isClosingBracket(prev.Symbol) {
+			tokens = append(tokens, lexeme{Token: types.NewToken("*"), Offset: offsets[start]})
+		}
+	}
+	fail := func(reason types.ParseErrorReason, start, end int, detail string) error {
+		return &types.ParseError{Offset: offsets[start], Token: s[offsets[start]:offsets[end]], Reason: reason, Detail: detail}
+	}
+
+	for i := 0; i < len(chars); i++ {
+		ch := chars[i]
+		if c.isDigit(ch) || ch == "." {
+			num, end, err := c.scanNumber(chars, i)
+			outOfRange := errors.Is(err, errOutOfRange)
+			if err != nil && !outOfRange {
+				return nil, fail(types.ReasonInvalidNumber, i, end, err.Error())
+			}
+			emit(types.NewExactToken(num, c.exactNumber(strings.Join(chars[i:end], ""))), i, end)
+			tokens[len(tokens)-1].OutOfRange = outOfRange
+			i = end - 1
+			continue
+		}
+
+		switch {
+		case c.isSpace(ch):
+		case c.isLetter(ch):
+			j := i + 1
+			for j < len(chars) && (c.isLetter(chars[j]) || c.isDigit(chars[j])) {
+				j++
 			}
-			if stack.Size() > 0 {
-				stack.SafePop()
+			name := strings.Join(chars[i:j], "")
+			implicitMul(i)
+			_, isVar := vars[name]
+			if _, isUserFunc := funcs[name]; c.isFunc(name) || isUserFunc && !isVar {
+				emit(types.NewToken(name), i, j)
+				i = j - 1
+				continue
 			}
+			val, ok := vars[name]
+			irrational := false
+			if !ok {
+				val, ok = constants[name]
+				irrational = ok
+			}
+			if !ok {
+				return nil, fail(types.ReasonUnknownIdentifier, i, j, "")
+			}
+			emit(types.NewVarToken(name, val), i, j)
+			tokens[len(tokens)-1].Irrational = irrational
+			i = j - 1
+		case ch == "-" && c.isPrefixPosition(tokens):
+			emit(types.NewToken("neg"), i, i+1)
+		case ch == "+" && c.isPrefixPosition(tokens):
+			emit(types.NewToken("pos"), i, i+1)
 		default:
-			for stack.Size() > 0 && c.precedence(stack.SafePeek().Symbol) >= c.precedence(t.Symbol) {
-				rpn = append(rpn, stack.SafePop())
+			symbol, n := c.matchSymbol(chars[i:])
+			if n == 0 {
+				return nil, fail(types.ReasonUnknownCharacter, i, i+1, "")
+			}
+			if c.isOpeningBracket(symbol) {
+				implicitMul(i)
 			}
-			stack.Push(t)
+			emit(types.NewToken(symbol), i, i+n)
+			i += n - 1
 		}
 	}
-	for stack.Size() > 0 {
-		rpn = append(rpn, stack.SafePop())
+	if err := c.checkExponents(tokens, vars); err != nil {
+		return nil, err
 	}
+	return tokens, nil
+}
 
-	if err := c.validateRPN(rpn); err != nil {
-		return nil, err
+// checkExponents returns *types.ParseError for the first literal with a signed exponent, e.g. "2e+1",
+// whose letter is bound by vars or used as a value in the tokens, as it could be meant as "2*e + 1" as well.
+func (c *Calculator) checkExponents(tokens []lexeme, vars map[string]float64) error {
+	for _, t := range tokens {
+		if !t.IsNumber || t.Name != "" {
+			continue
+		}
+		mantissa, letter, sign, exponent, ok := c.splitSignedExponent(t.Text)
+		if !ok {
+			continue
+		}
+		_, bound := vars[letter]
+		used := slices.ContainsFunc(tokens, func(u lexeme) bool { return u.IsNumber && u.Name == letter })
+		if !bound && !used {
+			continue
+		}
+		detail := fmt.Sprintf("ambiguous exponent as %q is used as a value, write %q to multiply by it",
+			letter, mantissa+letter+" "+sign+" "+exponent)
+		if sign == "+" {
+			detail += fmt.Sprintf(" or %q for the exponent", mantissa+letter+exponent)
+		}
+		return &types.ParseError{Offset: t.Offset, Token: t.Text, Reason: types.ReasonInvalidNumber, Detail: detail}
 	}
-	return rpn, nil
+	return nil
 }
 
-// validateRPN checks if the RPN expression is valid by simulating its evaluation.
-// Returns an error if the expression is malformed or contains invalid operations.
-func (c *Calculator) validateRPN(rpn []types.Token) error {
-	stack := stackx.New[types.Token]()
-	for _, token := range rpn {
-		if !c.isOp(token.Symbol) {
-			stack.Push(token)
+// splitSignedExponent splits a decimal literal with a signed exponent, e.g. "2.5e-3" into "2.5", "e", "-" and "3".
+func (c *Calculator) splitSignedExponent(literal string) (mantissa, letter, sign, exponent string, ok bool) {
+	if prefix := strings.ToLower(literal[:min(2, len(literal))]); prefix == "0x" || prefix == "0b" {
+		return "", "", "", "", false
+	}
+	i := strings.IndexAny(literal, "eE")
+	if i < 0 {
+		return "", "", "", "", false
+	}
+	for _, sign := range []string{"+", "-", "−"} {
+		if rest, found := strings.CutPrefix(literal[i+1:], sign); found {
+			return literal[:i], literal[i : i+1], sign, rest, true
+		}
+	}
+	return "", "", "", "", false
+}
+
+// checkLiterals returns *types.ParseError for the first number token that isn't allowed by the literals.
+func (c *Calculator) checkLiterals(tokens []lexeme, literals literals) error {
+	for _, t := range tokens {
+		if !t.IsNumber {
 			continue
 		}
+		switch {
+		case literals == floatLiterals && t.OutOfRange:
+			return &types.ParseError{Offset: t.Offset, Token: t.Text, Reason: types.ReasonInvalidNumber, Detail: errOutOfRange.Error()}
+		case literals == integerLiterals && (t.Exact == "" || strings.Contains(t.Exact, "/")):
+			return &types.ParseError{Offset: t.Offset, Token: t.Text, Reason: types.ReasonInvalidNumber, Detail: "not an integer"}
+		case literals == exactLiterals && t.Irrational:
+			return &types.ParseError{Offset: t.Offset, Token: t.Text, Reason: types.ReasonInvalidNumber, Detail: "irrational constant"}
+		}
+	}
+	return nil
+}
 
-		if stack.Size() < 2 {
-			return types.ErrInvalidExpr
+// exactNumber returns the exact value of a well-formed number literal, see types.Token.Exact.
+func (c *Calculator) exactNumber(literal string) string {
+	literal = strings.ReplaceAll(literal, "_", "")
+	if prefix := strings.ToLower(literal[:min(2, len(literal))]); prefix == "0x" || prefix == "0b" {
+		n, _ := new(big.Int).SetString(literal, 0)
+		return n.String()
+	}
+	r, _ := new(big.Rat).SetString(literal)
+	return r.RatString()
+}
+
+// negateExact negates the exact value of a number, see types.Token.Exact.
+func negateExact(exact string) string {
+	switch {
+	case exact == "" || exact == "0":
+		return exact
+	case strings.HasPrefix(exact, "-"):
+		return exact[1:]
+	default:
+		return "-" + exact
+	}
+}
+
+// scanNumber reads a number literal starting at chars[start] and returns its value
+// and the index right after the literal. Supported literals are decimal numbers with an optional
+// fraction and exponent ("42", ".5", "6.02E23", "1e-9"), hexadecimal ("0x1F") and binary ("0b101") integers.
+// Digits may be separated by "_" ("1_000_000").
+// If the literal is malformed, returns an error with the reason and the index right after the malformed literal.
+// If the literal exceeds the float64 range, returns errOutOfRange along with math.MaxFloat64 and the index after it.
+func (c *Calculator) scanNumber(chars []string, start int) (float64, int, error) {
+	i := start
+	fail := func(reason string) (float64, int, error) {
+		end := max(i, start+1)
+		for end < len(chars) && (c.isNumberChar(chars[end]) || chars[end] == ".") {
+			end++
 		}
+		return 0, end, errors.New(reason)
+	}
+	// digits reads a sequence of digits with single "_" between them and returns how many digits were read
+	digits := func(isDigit func(string) bool) (int, bool) {
+		n := 0
+		for i < len(chars) {
+			switch {
+			case isDigit(chars[i]):
+				n++
+			case chars[i] == "_" && n > 0 && i+1 < len(chars) && isDigit(chars[i+1]):
+			case chars[i] == "_":
+				return n, false
+			default:
+				return n, true
+			}
+			i++
+		}
+		return n, true
+	}
 
-		_, _ = stack.SafePop(), stack.SafePop()
-		stack.Push(types.NewToken("$"))
+	if chars[i] == "0" && i+1 < len(chars) {
+		base := 0
+		isDigit := c.isDigit
+		switch chars[i+1] {
+		case "x", "X":
+			base, isDigit = 16, c.isHexDigit
+		case "b", "B":
+			base, isDigit = 2, c.isBinDigit
+		}
+		if base != 0 {
+			i += 2
+			n, ok := digits(isDigit)
+			switch {
+			case !ok:
+				return fail("misplaced digit separator")
+			case n == 0:
+				return fail("missing digits")
+			case i < len(chars) && (c.isNumberChar(chars[i]) || chars[i] == "."):
+				return fail(fmt.Sprintf("invalid character %q for base %d", chars[i], base))
+			}
+			lit := strings.ReplaceAll(strings.Join(chars[start+2:i], ""), "_", "")
+			v, _ := new(big.Int).SetString(lit, base)
+			num, _ := new(big.Float).SetInt(v).Float64()
+			if math.IsInf(num, 0) {
+				return math.MaxFloat64, i, errOutOfRange
+			}
+			return num, i, nil
+		}
 	}
 
-	if stack.Size() != 1 {
-		return types.ErrInvalidExpr
+	intDigits, ok := digits(c.isDigit)
+	if !ok {
+		return fail("misplaced digit separator")
 	}
-	return nil
+	fracDigits := 0
+	if i < len(chars) && chars[i] == "." {
+		i++
+		if fracDigits, ok = digits(c.isDigit); !ok {
+			return fail("misplaced digit separator")
+		}
+		if i < len(chars) && chars[i] == "." {
+			return fail("multiple decimal points")
+		}
+	}
+	if intDigits+fracDigits == 0 {
+		return fail("missing digits")
+	}
+	if i < len(chars) && (chars[i] == "e" || chars[i] == "E") {
+		mantissaEnd := i
+		i++
+		if i < len(chars) && (chars[i] == "+" || chars[i] == "-") {
+			i++
+		}
+		n, ok := digits(c.isDigit)
+		switch {
+		case !ok:
+			return fail("misplaced digit separator")
+		case n == 0:
+			i = mantissaEnd // not an exponent, but an identifier multiplied by the number, e.g. "2e" or "2e+x"
+		case i < len(chars) && chars[i] == ".":
+			return fail("fractional exponent")
+		}
+	}
+
+	// A letter may follow as an identifier multiplied by the number, e.g. "3x"
+	lit := strings.ReplaceAll(strings.Join(chars[start:i], ""), "_", "")
+	num, err := strconv.ParseFloat(lit, 64)
+	if err != nil {
+		return math.MaxFloat64, i, errOutOfRange
+	}
+	return num, i, nil
 }
 
 func (c *Calculator) precedence(op string) int {
 	switch op {
-	case "+", "-":
+	case "||":
 		return 1
-	case "*", "/":
+	case "&&":
 		return 2
+	case "<", "<=", ">", ">=", "==", "!=":
+		return 3
+	case "+", "-":
+		return 4
+	case "*", "/", "%", "//":
+		return 5
+	case "neg", "pos", "not":
+		return 6
+	case "^":
+		return 7
 	default:
 		return 0
 	}
 }
 
-func (c *Calculator) isOp(s string) bool {
+func (c *Calculator) isBinaryOp(s string) bool {
 	switch s {
-	case "+", "-", "*", "/":
+	case "+", "-", "*", "/", "%", "//", "^", "<", "<=", ">", ">=", "==", "!=", "&&", "||":
 		return true
 	default:
 		return false
 	}
 }
+
+func (c *Calculator) isUnaryOp(s string) bool {
+	return s == "neg" || s == "not"
+}
+
+func (c *Calculator) isRightAssoc(op string) bool {
+	return op == "^"
+}
+
+func (c *Calculator) isFunc(s string) bool {
+	_, ok := functions[s]
+	return ok
+}
+
+func (c *Calculator) isOpeningBracket(s string) bool {
+	return s == "(" || s == "["
+}
+
+func (c *Calculator) isClosingBracket(s string) bool {
+	_, ok := brackets[s]
+	return ok
+}
+
+// matchSymbol returns the token of the longest symbol chars start with and the number of chars it takes.
+// Returns 0 if chars don't start with a known symbol.
+func (c *Calculator) matchSymbol(chars []string) (string, int) {
+	for n := min(2, len(chars)); n > 0; n-- {
+		if token, ok := symbols[strings.Join(chars[:n], "")]; ok {
+			return token, n
+		}
+	}
+	return "", 0
+}
+
+func (c *Calculator) isSpace(ch string) bool {
+	return ch == " " || ch == "\t" || ch == "\n" || ch == "\r"
+}
+
+func (c *Calculator) isDigit(ch string) bool {
+	return ch >= "0" && ch <= "9"
+}
+
+func (c *Calculator) isHexDigit(ch string) bool {
+	return c.isDigit(ch) || ch >= "a" && ch <= "f" || ch >= "A" && ch <= "F"
+}
+
+func (c *Calculator) isBinDigit(ch string) bool {
+	return ch == "0" || ch == "1"
+}
+
+// isNumberChar reports whether ch may continue a number literal, so it is reported as a part of a malformed one.
+func (c *Calculator) isNumberChar(ch string) bool {
+	return c.isDigit(ch) || c.isLetter(ch)
+}
+
+func (c *Calculator) isLetter(ch string) bool {
+	return ch >= "a" && ch <= "z" || ch >= "A" && ch <= "Z" || ch == "_"
+}
+
+// isPrefixPosition reports whether the next token would be a prefix one:
+// at the very beginning, after another operator or after an opening parenthesis.
+func (c *Calculator) isPrefixPosition(tokens []lexeme) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	prev := tokens[len(tokens)-1]
+	return !prev.IsNumber && !c.isClosingBracket(prev.Symbol)
+}
@@ -2,6 +2,9 @@ package calc
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
@@ -13,9 +16,29 @@ func TestCalculator_Parse(t *testing.T) {
 	errorIsErrInvalidExpr := func(t assert.TestingT, err error, msgAndArgs ...any) bool {
 		return assert.ErrorIs(t, err, types.ErrInvalidExpr, msgAndArgs...)
 	}
+	errorIsParseError := func(want types.ParseError) assert.ErrorAssertionFunc {
+		return func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+			var got *types.ParseError
+			return assert.ErrorAs(t, err, &got, msgAndArgs...) && assert.Equal(t, want, *got, msgAndArgs...)
+		}
+	}
+	errorIsErrInvalidNumber := func(s string) assert.ErrorAssertionFunc {
+		return func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+			return assert.ErrorIs(t, err, types.ErrInvalidNumber, msgAndArgs...) &&
+				assert.ErrorContains(t, err, s, msgAndArgs...)
+		}
+	}
+	errorIsErrInvalidVariable := func(s string) assert.ErrorAssertionFunc {
+		return func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+			return assert.ErrorIs(t, err, types.ErrInvalidVariable, msgAndArgs...) &&
+				assert.ErrorContains(t, err, s, msgAndArgs...)
+		}
+	}
 
 	type args struct {
-		s string
+		s     string
+		vars  map[string]float64
+		funcs map[string]types.Function
 	}
 	tests := []struct {
 		name     string
@@ -46,132 +69,1148 @@ func TestCalculator_Parse(t *testing.T) {
 			wantErr: assert.NoError,
 		},
 		{
-			name: "multiple operations",
-			args: args{s: "1+2*3"},
+			name: "multiple operations",
+			args: args{s: "1+2*3"},
+			want: []types.Token{
+				types.NewToken(1),
+				types.NewToken(2),
+				types.NewToken(3),
+				types.NewToken("*"),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "expression with parentheses",
+			args: args{s: "(1+2)*3"},
+			want: []types.Token{
+				types.NewToken(1),
+				types.NewToken(2),
+				types.NewToken("+"),
+				types.NewToken(3),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "nested parentheses",
+			args: args{s: "((1+2)*(3+4))"},
+			want: []types.Token{
+				types.NewToken(1),
+				types.NewToken(2),
+				types.NewToken("+"),
+				types.NewToken(3),
+				types.NewToken(4),
+				types.NewToken("+"),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "decimal numbers",
+			args: args{s: "1.5+2.5"},
+			want: []types.Token{
+				types.NewToken(1.5),
+				types.NewToken(2.5),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid expression: single operator",
+			args:    args{s: "+"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "invalid expression: mismatched parentheses",
+			args:    args{s: "(1+2"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:     "invalid expression: unbalanced operators",
+			args:     args{s: "1+2*"},
+			wantErr:  errorIsErrInvalidExpr,
+			skipTest: false,
+		},
+		{
+			name:    "invalid expression: double operators",
+			args:    args{s: "1*/2"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "invalid expression: single unary operator",
+			args:    args{s: "-"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "invalid expression: unary operator without operand",
+			args:    args{s: "2 * -"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "invalid expression: invalid decimals",
+			args:    args{s: "1..5+2"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "empty expression",
+			args:    args{s: ""},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "single number",
+			args:    args{s: "1"},
+			want:    []types.Token{types.NewToken(1)},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "single number in parentheses",
+			args:    args{s: "(7)"},
+			want:    []types.Token{types.NewToken(7)},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid expression: only non-numeric characters",
+			args:    args{s: "abracadabra"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name: "unary operators",
+			args: args{s: "-1 + 2"},
+			want: []types.Token{
+				types.NewToken(1),
+				types.NewToken("neg"),
+				types.NewToken(2),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "unary minus after binary operator",
+			args: args{s: "2 * -3"},
+			want: []types.Token{
+				types.NewToken(2),
+				types.NewToken(3),
+				types.NewToken("neg"),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "unary minus before parentheses",
+			args: args{s: "-(4+5)"},
+			want: []types.Token{
+				types.NewToken(4),
+				types.NewToken(5),
+				types.NewToken("+"),
+				types.NewToken("neg"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "double unary minus",
+			args: args{s: "--2*3"},
+			want: []types.Token{
+				types.NewToken(2),
+				types.NewToken("neg"),
+				types.NewToken("neg"),
+				types.NewToken(3),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "power",
+			args: args{s: "2*3^2"},
+			want: []types.Token{
+				types.NewToken(2),
+				types.NewToken(3),
+				types.NewToken(2),
+				types.NewToken("^"),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "power is right-associative",
+			args: args{s: "2^3^2"},
+			want: []types.Token{
+				types.NewToken(2),
+				types.NewToken(3),
+				types.NewToken(2),
+				types.NewToken("^"),
+				types.NewToken("^"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "power alias",
+			args: args{s: "2 ** 3 ** 2"},
+			want: []types.Token{
+				types.NewToken(2),
+				types.NewToken(3),
+				types.NewToken(2),
+				types.NewToken("^"),
+				types.NewToken("^"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "power binds tighter than unary minus",
+			args: args{s: "-2^-2"},
+			want: []types.Token{
+				types.NewToken(2),
+				types.NewToken(2),
+				types.NewToken("neg"),
+				types.NewToken("^"),
+				types.NewToken("neg"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "modulo and integer division",
+			args: args{s: "7 % 3 + 9 // 2 * 2"},
+			want: []types.Token{
+				types.NewToken(7),
+				types.NewToken(3),
+				types.NewToken("%"),
+				types.NewToken(9),
+				types.NewToken(2),
+				types.NewToken("//"),
+				types.NewToken(2),
+				types.NewToken("*"),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid expression: split integer division",
+			args:    args{s: "9 / / 2"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name: "function call",
+			args: args{s: "sqrt(2) * 3"},
+			want: []types.Token{
+				types.NewToken(2),
+				types.NewFuncToken("sqrt", 1),
+				types.NewToken(3),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "function call with multiple arguments",
+			args: args{s: "max(1, 2+3, -4)"},
+			want: []types.Token{
+				types.NewToken(1),
+				types.NewToken(2),
+				types.NewToken(3),
+				types.NewToken("+"),
+				types.NewToken(4),
+				types.NewToken("neg"),
+				types.NewFuncToken("max", 3),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "nested function calls",
+			args: args{s: "log(abs(min(-8, 2)), 2)"},
+			want: []types.Token{
+				types.NewToken(8),
+				types.NewToken("neg"),
+				types.NewToken(2),
+				types.NewFuncToken("min", 2),
+				types.NewFuncToken("abs", 1),
+				types.NewToken(2),
+				types.NewFuncToken("log", 2),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid expression: unknown function",
+			args:    args{s: "foo(1)"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "invalid expression: wrong number of function arguments",
+			args:    args{s: "sqrt(1, 2)"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "invalid expression: function without arguments",
+			args:    args{s: "max()"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "invalid expression: function without parentheses",
+			args:    args{s: "sqrt 4"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "invalid expression: empty function argument",
+			args:    args{s: "max(1,,2)"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "invalid expression: comma outside of function call",
+			args:    args{s: "(1, 2)"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "invalid expression: split power alias",
+			args:    args{s: "2 * * 3"},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name: "predefined constants",
+			args: args{s: "2 * pi + e"},
+			want: []types.Token{
+				types.NewToken(2),
+				types.NewVarToken("pi", math.Pi),
+				types.NewToken("*"),
+				types.NewVarToken("e", math.E),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "variables",
+			args: args{s: "amount * (1 + rate_1)", vars: map[string]float64{"amount": 100, "rate_1": 0.2}},
+			want: []types.Token{
+				types.NewVarToken("amount", 100),
+				types.NewToken(1),
+				types.NewVarToken("rate_1", 0.2),
+				types.NewToken("+"),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "variable shadows constant",
+			args: args{s: "-e", vars: map[string]float64{"e": 5}},
+			want: []types.Token{
+				types.NewVarToken("e", 5),
+				types.NewToken("neg"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid expression: unknown variable",
+			args:    args{s: "1 + rate", vars: map[string]float64{"amount": 100}},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "invalid expression: call of variable",
+			args:    args{s: "x(2)", vars: map[string]float64{"x": 1}},
+			wantErr: errorIsErrInvalidExpr,
+		},
+		{
+			name:    "invalid variable: not finite",
+			args:    args{s: "x + 1", vars: map[string]float64{"x": math.Inf(1)}},
+			wantErr: errorIsErrInvalidVariable(`"x" must be finite, got +Inf`),
+		},
+		{
+			name:    "invalid variable: NaN",
+			args:    args{s: "1", vars: map[string]float64{"unused": math.NaN()}},
+			wantErr: errorIsErrInvalidVariable(`"unused" must be finite, got NaN`),
+		},
+		{
+			name:    "invalid variable: not an identifier",
+			args:    args{s: "1", vars: map[string]float64{"x-1": 1}},
+			wantErr: errorIsErrInvalidVariable(`"x-1" isn't an identifier`),
+		},
+		{
+			name:    "invalid variable: built-in function",
+			args:    args{s: "sqrt(4)", vars: map[string]float64{"sqrt": 1}},
+			wantErr: errorIsErrInvalidVariable(`"sqrt" is a built-in function`),
+		},
+		{
+			name: "scientific notation",
+			args: args{s: "1e-9 + 6.02E23 * 2e+3"},
+			want: []types.Token{
+				types.NewToken(1e-9),
+				types.NewToken(6.02e23),
+				types.NewToken(2e3),
+				types.NewToken("*"),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "decimals without integer or fractional part",
+			args: args{s: ".5 + 5."},
+			want: []types.Token{
+				types.NewToken(0.5),
+				types.NewToken(5),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "digit separators",
+			args: args{s: "1_000_000 * 0.000_1"},
+			want: []types.Token{
+				types.NewToken(1000000),
+				types.NewToken(0.0001),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "hexadecimal and binary integers",
+			args: args{s: "0x1F + 0XFF_FF - 0b101"},
+			want: []types.Token{
+				types.NewToken(31),
+				types.NewToken(65535),
+				types.NewToken("+"),
+				types.NewToken(5),
+				types.NewToken("-"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "literals keep their exact values",
+			args: args{s: "0.1 + 1.000_000_000_000_000_000_1 * 0XFF_FF_FF_FF_FF_FF_FF_FF"},
+			want: []types.Token{
+				types.NewExactToken(0.1, "1/10"),
+				types.NewExactToken(1, "10000000000000000001/10000000000000000000"),
+				types.NewExactToken(18446744073709551615, "18446744073709551615"),
+				types.NewToken("*"),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "hexadecimal integers beyond 64 bits",
+			args: args{s: "0x1_0000_0000_0000_0000"},
+			want: []types.Token{
+				types.NewExactToken(18446744073709551616, "18446744073709551616"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid number: multiple decimal points",
+			args:    args{s: "1 + 1.2.3"},
+			wantErr: errorIsErrInvalidNumber(`invalid number "1.2.3" at position 4: multiple decimal points`),
+		},
+		{
+			name:    "exponent without digits is a multiplied constant",
+			args:    args{s: "2e+"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 2, Token: "+", Reason: types.ReasonDanglingOperator}),
+		},
+		{
+			name:    "invalid number: fractional exponent",
+			args:    args{s: "1e2.5"},
+			wantErr: errorIsErrInvalidNumber(`invalid number "1e2.5" at position 0: fractional exponent`),
+		},
+		{
+			name:    "invalid number: misplaced digit separator",
+			args:    args{s: "1__000"},
+			wantErr: errorIsErrInvalidNumber(`invalid number "1__000" at position 0: misplaced digit separator`),
+		},
+		{
+			name:    "invalid number: trailing digit separator",
+			args:    args{s: "1_ + 2"},
+			wantErr: errorIsErrInvalidNumber(`invalid number "1_" at position 0: misplaced digit separator`),
+		},
+		{
+			name:    "invalid number: invalid hexadecimal digit",
+			args:    args{s: "0x1G"},
+			wantErr: errorIsErrInvalidNumber(`invalid number "0x1G" at position 0: invalid character "G" for base 16`),
+		},
+		{
+			name:    "invalid number: invalid binary digit",
+			args:    args{s: "0b102"},
+			wantErr: errorIsErrInvalidNumber(`invalid number "0b102" at position 0: invalid character "2" for base 2`),
+		},
+		{
+			name:    "invalid number: prefix without digits",
+			args:    args{s: "0x"},
+			wantErr: errorIsErrInvalidNumber(`invalid number "0x" at position 0: missing digits`),
+		},
+		{
+			name:    "invalid number: out of range",
+			args:    args{s: "1e400"},
+			wantErr: errorIsErrInvalidNumber(`invalid number "1e400" at position 0: out of range`),
+		},
+		{
+			name:    "letters after digits are a multiplied identifier",
+			args:    args{s: "12abc"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 2, Token: "abc", Reason: types.ReasonUnknownIdentifier}),
+		},
+		{
+			name: "implicit multiplication",
+			args: args{s: "2(3+4) + 3x + (1+2)[3] - 2sqrt(4)", vars: map[string]float64{"x": 5}},
+			want: []types.Token{
+				types.NewToken(2),
+				types.NewToken(3),
+				types.NewToken(4),
+				types.NewToken("+"),
+				types.NewToken("*"),
+				types.NewToken(3),
+				types.NewVarToken("x", 5),
+				types.NewToken("*"),
+				types.NewToken("+"),
+				types.NewToken(1),
+				types.NewToken(2),
+				types.NewToken("+"),
+				types.NewToken(3),
+				types.NewToken("*"),
+				types.NewToken("+"),
+				types.NewToken(2),
+				types.NewToken(4),
+				types.NewFuncToken("sqrt", 1),
+				types.NewToken("*"),
+				types.NewToken("-"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "implicit multiplication after an exponent",
+			args: args{s: "1e3pi"},
+			want: []types.Token{
+				types.NewToken(1000),
+				types.NewVarToken("pi", math.Pi),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "implicit multiplication by e",
+			args: args{s: "2e + 1 - 4e-x", vars: map[string]float64{"x": 5}},
+			want: []types.Token{
+				types.NewToken(2),
+				types.NewVarToken("e", math.E),
+				types.NewToken("*"),
+				types.NewToken(1),
+				types.NewToken("+"),
+				types.NewToken(4),
+				types.NewVarToken("e", math.E),
+				types.NewToken("*"),
+				types.NewToken("-"),
+				types.NewVarToken("x", 5),
+				types.NewToken("-"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "signed exponent without e used as a value",
+			args: args{s: "2e+1 + 2E-1"},
+			want: []types.Token{
+				types.NewToken(20),
+				types.NewToken(0.2),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "signed exponent ambiguous with e used as a value",
+			args: args{s: "2e + 3e+1"},
+			wantErr: errorIsParseError(types.ParseError{
+				Offset: 5,
+				Token:  "3e+1",
+				Reason: types.ReasonInvalidNumber,
+				Detail: `ambiguous exponent as "e" is used as a value, write "3e + 1" to multiply by it or "3e1" for the exponent`,
+			}),
+		},
+		{
+			name: "signed exponent ambiguous with e bound by a variable",
+			args: args{s: "2e−1", vars: map[string]float64{"e": 3}},
+			wantErr: errorIsParseError(types.ParseError{
+				Offset: 0,
+				Token:  "2e−1",
+				Reason: types.ReasonInvalidNumber,
+				Detail: `ambiguous exponent as "e" is used as a value, write "2e − 1" to multiply by it`,
+			}),
+		},
+		{
+			name: "signed exponent with another letter than e used as a value",
+			args: args{s: "2E+1 * e"},
+			want: []types.Token{
+				types.NewToken(20),
+				types.NewVarToken("e", math.E),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "no implicit multiplication between identifiers",
+			args:    args{s: "x (1)", vars: map[string]float64{"x": 5}},
+			wantErr: errorIsParseError(types.ParseError{Offset: 2, Token: "(", Reason: types.ReasonMissingOperator}),
+		},
+		{
+			name: "alternative operator glyphs",
+			args: args{s: "6 × 7 ÷ 2 − 3·−1"},
+			want: []types.Token{
+				types.NewToken(6),
+				types.NewToken(7),
+				types.NewToken("*"),
+				types.NewToken(2),
+				types.NewToken("/"),
+				types.NewToken(3),
+				types.NewToken(1),
+				types.NewToken("neg"),
+				types.NewToken("*"),
+				types.NewToken("-"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "parse error: glyphs are reported as written",
+			args:    args{s: "6 × 7 ÷"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 7, Token: "÷", Reason: types.ReasonDanglingOperator}),
+		},
+		{
+			name:    "parse error: single unary plus",
+			args:    args{s: "+"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 0, Token: "+", Reason: types.ReasonDanglingOperator}),
+		},
+		{
+			name:    "parse error: dangling unary plus",
+			args:    args{s: "2 * +"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 4, Token: "+", Reason: types.ReasonDanglingOperator}),
+		},
+		{
+			name:    "parse error: empty input",
+			args:    args{s: "  "},
+			wantErr: errorIsParseError(types.ParseError{Reason: types.ReasonEmptyInput}),
+		},
+		{
+			name:    "parse error: unknown character",
+			args:    args{s: "2 $ 3"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 2, Token: "$", Reason: types.ReasonUnknownCharacter}),
+		},
+		{
+			name:    "parse error: comparison is not an operator",
+			args:    args{s: "1 = 1"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 2, Token: "=", Reason: types.ReasonUnknownCharacter}),
+		},
+		{
+			name: "user-defined function is expanded",
+			args: args{
+				s:     "vat(price + 1)",
+				vars:  map[string]float64{"price": 100, "x": 5},
+				funcs: map[string]types.Function{"vat": {Name: "vat", Params: []string{"x"}, Body: "x * 1.2"}},
+			},
+			want: []types.Token{
+				types.NewVarToken("price", 100),
+				types.NewToken(1),
+				types.NewToken("+"),
+				types.NewExactToken(1.2, "6/5"),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "user-defined function calls another one",
+			args: args{
+				s: "2gross(3, 4)",
+				funcs: map[string]types.Function{
+					"vat":   {Name: "vat", Params: []string{"x"}, Body: "x * 1.2"},
+					"gross": {Name: "gross", Params: []string{"x", "fee"}, Body: "vat(x) + fee"},
+				},
+			},
+			want: []types.Token{
+				types.NewToken(2),
+				types.NewToken(3),
+				types.NewExactToken(1.2, "6/5"),
+				types.NewToken("*"),
+				types.NewToken(4),
+				types.NewToken("+"),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "parse error: wrong number of arguments of a user-defined function",
+			args: args{
+				s:     "1 + vat(1, 2)",
+				funcs: map[string]types.Function{"vat": {Name: "vat", Params: []string{"x"}, Body: "x * 1.2"}},
+			},
+			wantErr: errorIsParseError(types.ParseError{Offset: 4, Token: "vat", Reason: types.ReasonInvalidFunctionCall}),
+		},
+		{
+			name: "parse error: invalid body of a user-defined function",
+			args: args{
+				s:     "tax(1)",
+				funcs: map[string]types.Function{"tax": {Name: "tax", Params: []string{"x"}, Body: "x * rate"}},
+			},
+			wantErr: errorIsParseError(types.ParseError{
+				Token:  "tax",
+				Reason: types.ReasonInvalidFunctionCall,
+				Detail: `in the body of "tax": unknown identifier "rate" at position 4`,
+			}),
+		},
+		{
+			name: "parse error: recursive user-defined functions",
+			args: args{
+				s: "f(1)",
+				funcs: map[string]types.Function{
+					"f": {Name: "f", Params: []string{"x"}, Body: "g(x) + 1"},
+					"g": {Name: "g", Params: []string{"x"}, Body: "f(x) * 2"},
+				},
+			},
+			wantErr: errorIsParseError(types.ParseError{
+				Token:  "f",
+				Reason: types.ReasonInvalidFunctionCall,
+				Detail: `in the body of "f": invalid function call "g" at position 0: ` +
+					`in the body of "g": invalid function call "f" at position 0: recursive call`,
+			}),
+		},
+		{
+			name: "whitespaces",
+			args: args{s: "\t1 +\r\n2 "},
+			want: []types.Token{
+				types.NewToken(1),
+				types.NewToken(2),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "parse error: offset in bytes",
+			args:    args{s: "sqrt(4) + √4"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 10, Token: "√", Reason: types.ReasonUnknownCharacter}),
+		},
+		{
+			name:    "parse error: unknown identifier",
+			args:    args{s: "1 + rate"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 4, Token: "rate", Reason: types.ReasonUnknownIdentifier}),
+		},
+		{
+			name: "parse error: invalid number",
+			args: args{s: "1 + 1.2.3"},
+			wantErr: errorIsParseError(types.ParseError{
+				Offset: 4, Token: "1.2.3", Reason: types.ReasonInvalidNumber, Detail: "multiple decimal points",
+			}),
+		},
+		{
+			name:    "parse error: unclosed parenthesis",
+			args:    args{s: "(1+2"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 0, Token: "(", Reason: types.ReasonUnbalancedParen}),
+		},
+		{
+			name:    "parse error: unopened parenthesis",
+			args:    args{s: "1+2)"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 3, Token: ")", Reason: types.ReasonUnbalancedParen}),
+		},
+		{
+			name:    "parse error: leftmost unclosed parenthesis",
+			args:    args{s: "2 * ((1+2)"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 4, Token: "(", Reason: types.ReasonUnbalancedParen}),
+		},
+		{
+			name:    "parse error: first unopened parenthesis",
+			args:    args{s: "(1+2)) * (3"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 5, Token: ")", Reason: types.ReasonUnbalancedParen}),
+		},
+		{
+			name: "parse error: mismatched brackets",
+			args: args{s: "[1 + (2 * 3])"},
+			wantErr: errorIsParseError(types.ParseError{
+				Offset: 11,
+				Token:  "]",
+				Reason: types.ReasonUnbalancedParen,
+				Detail: `"(" at position 5 is closed by "]"`,
+			}),
+		},
+		{
+			name:    "parse error: function call with square brackets",
+			args:    args{s: "max[1, 2]"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 0, Token: "max", Reason: types.ReasonInvalidFunctionCall}),
+		},
+		{
+			name: "square brackets",
+			args: args{s: "[1 + (2 - 3)] * max([4], 5)"},
+			want: []types.Token{
+				types.NewToken(1),
+				types.NewToken(2),
+				types.NewToken(3),
+				types.NewToken("-"),
+				types.NewToken("+"),
+				types.NewToken(4),
+				types.NewToken(5),
+				types.NewFuncToken("max", 2),
+				types.NewToken("*"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "parse error: trailing operator",
+			args:    args{s: "1 + 2 *"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 6, Token: "*", Reason: types.ReasonDanglingOperator}),
+		},
+		{
+			name:    "parse error: operator without left operand",
+			args:    args{s: "(* 2)"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 1, Token: "*", Reason: types.ReasonDanglingOperator}),
+		},
+		{
+			name:    "parse error: operator before closing parenthesis",
+			args:    args{s: "(1 -) * 2"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 3, Token: "-", Reason: types.ReasonDanglingOperator}),
+		},
+		{
+			name:    "parse error: missing operator",
+			args:    args{s: "1 2"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 2, Token: "2", Reason: types.ReasonMissingOperator}),
+		},
+		{
+			name:    "parse error: wrong number of function arguments",
+			args:    args{s: "1 + sqrt(1, 2)"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 4, Token: "sqrt", Reason: types.ReasonInvalidFunctionCall}),
+		},
+		{
+			name:    "parse error: empty parentheses",
+			args:    args{s: "2 * ()"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 5, Token: ")", Reason: types.ReasonUnexpectedToken}),
+		},
+		{
+			name: "unary plus",
+			args: args{s: "1++2 * +-3 ^ +2"},
+			want: []types.Token{
+				types.NewToken(1),
+				types.NewToken(2),
+				types.NewToken(3),
+				types.NewToken(2),
+				types.NewToken("^"),
+				types.NewToken("neg"),
+				types.NewToken("*"),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.skipTest {
+				t.Skipf("skip test: Parse(%v)", tt.args.s)
+			}
+
+			c := NewCalculator()
+			got, err := c.Parse(tt.args.s, tt.args.vars, tt.args.funcs)
+			if !tt.wantErr(t, err, fmt.Sprintf("Parse(%v)", tt.args.s)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "Parse(%v)", tt.args.s)
+		})
+	}
+}
+
+func TestCalculator_ParseInt(t *testing.T) {
+	errorIsParseError := func(want types.ParseError) assert.ErrorAssertionFunc {
+		return func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+			var got *types.ParseError
+			return assert.ErrorAs(t, err, &got, msgAndArgs...) && assert.Equal(t, want, *got, msgAndArgs...) &&
+				assert.ErrorIs(t, err, types.ErrInvalidNumber, msgAndArgs...)
+		}
+	}
+
+	type args struct {
+		s     string
+		vars  map[string]float64
+		funcs map[string]types.Function
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []types.Token
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "integer literals and variables",
+			args: args{s: "x * 1e3 + 0xFF // 2.0", vars: map[string]float64{"x": 7}},
+			want: []types.Token{
+				types.NewVarToken("x", 7),
+				types.NewExactToken(1000, "1000"),
+				types.NewToken("*"),
+				types.NewExactToken(255, "255"),
+				types.NewExactToken(2, "2"),
+				types.NewToken("//"),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "integers beyond float64 precision",
+			args: args{s: "9007199254740993 ^ 3"},
+			want: []types.Token{
+				types.NewExactToken(9007199254740993, "9007199254740993"),
+				types.NewExactToken(3, "3"),
+				types.NewToken("^"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "integers beyond float64 range",
+			args: args{s: "1" + strings.Repeat("0", 400) + " + 0x" + strings.Repeat("F", 300)},
+			want: []types.Token{
+				types.NewExactToken(math.MaxFloat64, "1"+strings.Repeat("0", 400)),
+				types.NewExactToken(math.MaxFloat64, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 1200), big.NewInt(1)).String()),
+				types.NewToken("+"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "fractional literal",
+			args:    args{s: "2 * 1.5"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 4, Token: "1.5", Reason: types.ReasonInvalidNumber, Detail: "not an integer"}),
+		},
+		{
+			name:    "fractional literal with exponent",
+			args:    args{s: "25e-1"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 0, Token: "25e-1", Reason: types.ReasonInvalidNumber, Detail: "not an integer"}),
+		},
+		{
+			name:    "fractional variable",
+			args:    args{s: "1 + rate", vars: map[string]float64{"rate": 0.2}},
+			wantErr: errorIsParseError(types.ParseError{Offset: 4, Token: "rate", Reason: types.ReasonInvalidNumber, Detail: "not an integer"}),
+		},
+		{
+			name:    "irrational constant",
+			args:    args{s: "pi"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 0, Token: "pi", Reason: types.ReasonInvalidNumber, Detail: "not an integer"}),
+		},
+		{
+			name: "fractional literal in the body of a user-defined function",
+			args: args{
+				s:     "vat(7)",
+				funcs: map[string]types.Function{"vat": {Name: "vat", Params: []string{"x"}, Body: "x * 1.2"}},
+			},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				var got *types.ParseError
+				return assert.ErrorAs(t, err, &got, msgAndArgs...) && assert.Equal(t, types.ParseError{
+					Token:  "vat",
+					Reason: types.ReasonInvalidFunctionCall,
+					Detail: `in the body of "vat": invalid number "1.2" at position 4: not an integer`,
+				}, *got, msgAndArgs...)
+			},
+		},
+		{
+			name: "integer user-defined function",
+			args: args{
+				s:     "sq(7)",
+				funcs: map[string]types.Function{"sq": {Name: "sq", Params: []string{"x"}, Body: "x ^ 2"}},
+			},
+			want: []types.Token{
+				types.NewExactToken(7, "7"),
+				types.NewExactToken(2, "2"),
+				types.NewToken("^"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "invalid expression",
+			args: args{s: "1 +"},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrInvalidExpr, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCalculator()
+			got, err := c.ParseInt(tt.args.s, tt.args.vars, tt.args.funcs)
+			if !tt.wantErr(t, err, fmt.Sprintf("ParseInt(%v)", tt.args.s)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "ParseInt(%v)", tt.args.s)
+		})
+	}
+}
+
+func TestCalculator_ParseExact(t *testing.T) {
+	errorIsParseError := func(want types.ParseError) assert.ErrorAssertionFunc {
+		return func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+			var got *types.ParseError
+			return assert.ErrorAs(t, err, &got, msgAndArgs...) && assert.Equal(t, want, *got, msgAndArgs...)
+		}
+	}
+
+	type args struct {
+		s     string
+		vars  map[string]float64
+		funcs map[string]types.Function
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []types.Token
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "fractions and variables",
+			args: args{s: "x * 0.1 + 2.5e-1", vars: map[string]float64{"x": 0.5}},
 			want: []types.Token{
-				types.NewToken(1),
-				types.NewToken(2),
-				types.NewToken(3),
+				types.NewVarToken("x", 0.5),
+				types.NewExactToken(0.1, "1/10"),
 				types.NewToken("*"),
+				types.NewExactToken(0.25, "1/4"),
 				types.NewToken("+"),
 			},
 			wantErr: assert.NoError,
 		},
 		{
-			name: "expression with parentheses",
-			args: args{s: "(1+2)*3"},
+			name: "literals beyond float64 range",
+			args: args{s: "1e400 / 3"},
 			want: []types.Token{
-				types.NewToken(1),
-				types.NewToken(2),
-				types.NewToken("+"),
-				types.NewToken(3),
-				types.NewToken("*"),
+				types.NewExactToken(math.MaxFloat64, "1"+strings.Repeat("0", 400)),
+				types.NewExactToken(3, "3"),
+				types.NewToken("/"),
 			},
 			wantErr: assert.NoError,
 		},
 		{
-			name: "nested parentheses",
-			args: args{s: "((1+2)*(3+4))"},
-			want: []types.Token{
-				types.NewToken(1),
-				types.NewToken(2),
-				types.NewToken("+"),
-				types.NewToken(3),
-				types.NewToken(4),
-				types.NewToken("+"),
-				types.NewToken("*"),
-			},
-			wantErr: assert.NoError,
+			name:    "irrational constant",
+			args:    args{s: "2 * pi"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 4, Token: "pi", Reason: types.ReasonInvalidNumber, Detail: "irrational constant"}),
 		},
 		{
-			name: "decimal numbers",
-			args: args{s: "1.5+2.5"},
+			name:    "irrational constant multiplied implicitly",
+			args:    args{s: "2e"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 1, Token: "e", Reason: types.ReasonInvalidNumber, Detail: "irrational constant"}),
+		},
+		{
+			name: "constant redefined by a variable",
+			args: args{s: "e / 2", vars: map[string]float64{"e": 3}},
 			want: []types.Token{
-				types.NewToken(1.5),
-				types.NewToken(2.5),
-				types.NewToken("+"),
+				types.NewVarToken("e", 3),
+				types.NewExactToken(2, "2"),
+				types.NewToken("/"),
 			},
 			wantErr: assert.NoError,
 		},
 		{
-			name:    "invalid expression: single operator",
-			args:    args{s: "+"},
-			wantErr: errorIsErrInvalidExpr,
+			name: "irrational constant in the body of a user-defined function",
+			args: args{
+				s:     "area(2)",
+				funcs: map[string]types.Function{"area": {Name: "area", Params: []string{"r"}, Body: "pi * r ^ 2"}},
+			},
+			wantErr: errorIsParseError(types.ParseError{
+				Token:  "area",
+				Reason: types.ReasonInvalidFunctionCall,
+				Detail: `in the body of "area": invalid number "pi" at position 0: irrational constant`,
+			}),
 		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCalculator()
+			got, err := c.ParseExact(tt.args.s, tt.args.vars, tt.args.funcs)
+			if !tt.wantErr(t, err, fmt.Sprintf("ParseExact(%v)", tt.args.s)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "ParseExact(%v)", tt.args.s)
+		})
+	}
+}
+
+func TestCalculator_ParseAST(t *testing.T) {
+	num := func(v float64) types.Node { return &types.NumberNode{Value: v, Exact: types.ExactFloat(v)} }
+	bin := func(op string, l, r types.Node) types.Node { return &types.BinaryNode{Op: op, Left: l, Right: r} }
+
+	type args struct {
+		s    string
+		vars map[string]float64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    types.Node
+		wantErr assert.ErrorAssertionFunc
+	}{
 		{
-			name:    "invalid expression: mismatched parentheses",
-			args:    args{s: "(1+2"},
-			wantErr: errorIsErrInvalidExpr,
+			name:    "precedence",
+			args:    args{s: "1 + 2 * 3 - 4"},
+			want:    bin("-", bin("+", num(1), bin("*", num(2), num(3))), num(4)),
+			wantErr: assert.NoError,
 		},
 		{
-			name:     "invalid expression: unbalanced operators",
-			args:     args{s: "1+2*"},
-			wantErr:  errorIsErrInvalidExpr,
-			skipTest: false,
+			name:    "power is right-associative",
+			args:    args{s: "2 ^ 3 ^ 2"},
+			want:    bin("^", num(2), bin("^", num(3), num(2))),
+			wantErr: assert.NoError,
 		},
 		{
-			name:    "invalid expression: double operators",
-			args:    args{s: "1++2"},
-			wantErr: errorIsErrInvalidExpr,
+			name:    "unary minus binds weaker than power",
+			args:    args{s: "-2^2 * 3"},
+			want:    bin("*", &types.UnaryNode{Op: "neg", Operand: bin("^", num(2), num(2))}, num(3)),
+			wantErr: assert.NoError,
 		},
 		{
-			name:    "invalid expression: invalid decimals",
-			args:    args{s: "1..5+2"},
-			wantErr: errorIsErrInvalidExpr,
+			name: "comparison and logical operators",
+			args: args{s: "1 + 2 > 3 && !0 || 4 == 5"},
+			want: bin("||",
+				bin("&&", bin(">", bin("+", num(1), num(2)), num(3)), &types.UnaryNode{Op: "not", Operand: num(0)}),
+				bin("==", num(4), num(5)),
+			),
+			wantErr: assert.NoError,
 		},
 		{
-			name:    "empty expression",
-			args:    args{s: ""},
-			wantErr: errorIsErrInvalidExpr,
+			name: "conditional",
+			args: args{s: "if(x >= 100, x * 0.9, x)", vars: map[string]float64{"x": 5}},
+			want: &types.CallNode{Func: "if", Args: []types.Node{
+				bin(">=", &types.NumberNode{Value: 5, Name: "x", Exact: "5"}, num(100)),
+				bin("*", &types.NumberNode{Value: 5, Name: "x", Exact: "5"}, num(0.9)),
+				&types.NumberNode{Value: 5, Name: "x", Exact: "5"},
+			}},
+			wantErr: assert.NoError,
 		},
-		// FIXME: unexpected behaviour
 		{
-			name:     "invalid expression: single number",
-			args:     args{s: "1"},
-			wantErr:  errorIsErrInvalidExpr,
-			skipTest: true,
+			name: "logical not only as a prefix",
+			args: args{s: "1 ! 2"},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				var got *types.ParseError
+				return assert.ErrorAs(t, err, &got, msgAndArgs...) &&
+					assert.Equal(t, types.ParseError{Offset: 2, Token: "!", Reason: types.ReasonMissingOperator}, *got, msgAndArgs...)
+			},
 		},
 		{
-			name:     "invalid expression: only non-numeric characters",
-			args:     args{s: "abracadabra"},
-			wantErr:  errorIsErrInvalidExpr,
-			skipTest: true,
+			name: "function call with variables",
+			args: args{s: "max(x, [1 + 2]) / pi", vars: map[string]float64{"x": 5}},
+			want: bin("/",
+				&types.CallNode{Func: "max", Args: []types.Node{
+					&types.NumberNode{Value: 5, Name: "x", Exact: "5"},
+					bin("+", num(1), num(2)),
+				}},
+				&types.NumberNode{Value: math.Pi, Name: "pi", Exact: types.ExactFloat(math.Pi)},
+			),
+			wantErr: assert.NoError,
 		},
 		{
-			name:     "unary operators",
-			args:     args{s: "-1 + 2"},
-			wantErr:  assert.NoError,
-			skipTest: true,
+			name: "invalid expression",
+			args: args{s: "max(1, 2"},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrInvalidExpr, msgAndArgs...)
+			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.skipTest {
-				t.Skipf("skip test: Parse(%v)", tt.args.s)
-			}
-
 			c := NewCalculator()
-			got, err := c.Parse(tt.args.s)
-			if !tt.wantErr(t, err, fmt.Sprintf("Parse(%v)", tt.args.s)) {
+			got, err := c.ParseAST(tt.args.s, tt.args.vars, nil)
+			if !tt.wantErr(t, err, fmt.Sprintf("ParseAST(%v)", tt.args.s)) {
 				return
 			}
-			assert.Equalf(t, tt.want, got, "Parse(%v)", tt.args.s)
+			assert.Equalf(t, tt.want, got, "ParseAST(%v)", tt.args.s)
 		})
 	}
 }
 
 func TestCalculator_Schedule(t *testing.T) {
 	mustParse := func(s string) []types.Token {
-		return lo.Must(NewCalculator().Parse(s))
+		return lo.Must(NewCalculator().Parse(s, nil, nil))
 	}
 
 	type args struct {
 		rpn []types.Token
 	}
 	tests := []struct {
-		name string
-		args args
-		want []types.Task
+		name      string
+		args      args
+		want      []types.Task
+		wantValue float64
 	}{
 		{
 			name: "simple addition",
@@ -239,6 +1278,269 @@ func TestCalculator_Schedule(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "negative literal is folded",
+			args: args{rpn: mustParse("-1 + 2")},
+			want: []types.Task{
+				{
+					ID:        "mock-id",
+					Operation: "+",
+					Arg1:      -1,
+					Arg2:      2,
+				},
+			},
+		},
+		{
+			name: "negation of subexpression",
+			args: args{rpn: mustParse("-(4+5)")},
+			want: []types.Task{
+				{
+					ID:        "mock-id-1",
+					Operation: "+",
+					Arg1:      4,
+					Arg2:      5,
+				},
+				{
+					ID:            "mock-id-2",
+					Operation:     "neg",
+					ParentTask1ID: "mock-id-1",
+				},
+			},
+		},
+		{
+			name: "function call",
+			args: args{rpn: mustParse("max(1, 2*3)")},
+			want: []types.Task{
+				{
+					ID:        "mock-id-1",
+					Operation: "*",
+					Arg1:      2,
+					Arg2:      3,
+				},
+				{
+					ID:            "mock-id-2",
+					Operation:     "max",
+					ParentTaskIDs: []string{"", "mock-id-1"},
+					Args:          []float64{1, 0},
+				},
+			},
+		},
+		{
+			name: "conditional",
+			args: args{rpn: mustParse("if(1 > 2, 3 * 4, 5 + 6)")},
+			want: []types.Task{
+				{
+					ID:        "mock-id-1",
+					Operation: ">",
+					Arg1:      1,
+					Arg2:      2,
+				},
+				{
+					ID:              "mock-id-2",
+					Operation:       "*",
+					Arg1:            3,
+					Arg2:            4,
+					ConditionTaskID: "mock-id-1",
+					ConditionValue:  true,
+				},
+				{
+					ID:              "mock-id-3",
+					Operation:       "+",
+					Arg1:            5,
+					Arg2:            6,
+					ConditionTaskID: "mock-id-1",
+					ConditionValue:  false,
+				},
+				{
+					ID:            "mock-id-4",
+					Operation:     "if",
+					ParentTaskIDs: []string{"mock-id-1", "mock-id-2", "mock-id-3"},
+					Args:          []float64{0, 0, 0},
+				},
+			},
+		},
+		{
+			name: "conditional with literal condition",
+			args: args{rpn: mustParse("if(0, 3 * 4, 5 + 6)")},
+			want: []types.Task{
+				{
+					ID:        "mock-id-1",
+					Operation: "+",
+					Arg1:      5,
+					Arg2:      6,
+				},
+			},
+		},
+		{
+			name: "subexpressions aren't shared between branches",
+			args: args{rpn: mustParse("if(1 < 2, 3 + 4, (3 + 4) * 2) + (3 + 4)")},
+			want: []types.Task{
+				{
+					ID:        "mock-id-1",
+					Operation: "<",
+					Arg1:      1,
+					Arg2:      2,
+				},
+				{
+					ID:              "mock-id-2",
+					Operation:       "+",
+					Arg1:            3,
+					Arg2:            4,
+					ConditionTaskID: "mock-id-1",
+					ConditionValue:  true,
+				},
+				{
+					ID:              "mock-id-3",
+					Operation:       "+",
+					Arg1:            3,
+					Arg2:            4,
+					ConditionTaskID: "mock-id-1",
+					ConditionValue:  false,
+				},
+				{
+					ID:              "mock-id-4",
+					Operation:       "*",
+					ParentTask1ID:   "mock-id-3",
+					Arg2:            2,
+					ConditionTaskID: "mock-id-1",
+					ConditionValue:  false,
+				},
+				{
+					ID:            "mock-id-5",
+					Operation:     "if",
+					ParentTaskIDs: []string{"mock-id-1", "mock-id-2", "mock-id-4"},
+					Args:          []float64{0, 0, 0},
+				},
+				{
+					ID:        "mock-id-6",
+					Operation: "+",
+					Arg1:      3,
+					Arg2:      4,
+				},
+				{
+					ID:            "mock-id-7",
+					Operation:     "+",
+					ParentTask1ID: "mock-id-5",
+					ParentTask2ID: "mock-id-6",
+				},
+			},
+		},
+		{
+			name: "common subexpressions",
+			args: args{rpn: mustParse("(1+2)*(1+2)+(1+2)/2")},
+			want: []types.Task{
+				{
+					ID:        "mock-id-1",
+					Operation: "+",
+					Arg1:      1,
+					Arg2:      2,
+				},
+				{
+					ID:            "mock-id-2",
+					Operation:     "*",
+					ParentTask1ID: "mock-id-1",
+					ParentTask2ID: "mock-id-1",
+				},
+				{
+					ID:            "mock-id-3",
+					Operation:     "/",
+					ParentTask1ID: "mock-id-1",
+					Arg2:          2,
+				},
+				{
+					ID:            "mock-id-4",
+					Operation:     "+",
+					ParentTask1ID: "mock-id-2",
+					ParentTask2ID: "mock-id-3",
+				},
+			},
+		},
+		{
+			name: "nested common subexpressions",
+			args: args{rpn: mustParse("max(-(1+2), 3) - max(-(1+2), 3)")},
+			want: []types.Task{
+				{
+					ID:        "mock-id-1",
+					Operation: "+",
+					Arg1:      1,
+					Arg2:      2,
+				},
+				{
+					ID:            "mock-id-2",
+					Operation:     "neg",
+					ParentTask1ID: "mock-id-1",
+				},
+				{
+					ID:            "mock-id-3",
+					Operation:     "max",
+					ParentTaskIDs: []string{"mock-id-2", ""},
+					Args:          []float64{0, 3},
+				},
+				{
+					ID:            "mock-id-4",
+					Operation:     "-",
+					ParentTask1ID: "mock-id-3",
+					ParentTask2ID: "mock-id-3",
+				},
+			},
+		},
+		{
+			name: "similar but different subexpressions",
+			args: args{rpn: mustParse("(1+2)*(2+1)")},
+			want: []types.Task{
+				{
+					ID:        "mock-id-1",
+					Operation: "+",
+					Arg1:      1,
+					Arg2:      2,
+				},
+				{
+					ID:        "mock-id-2",
+					Operation: "+",
+					Arg1:      2,
+					Arg2:      1,
+				},
+				{
+					ID:            "mock-id-3",
+					Operation:     "*",
+					ParentTask1ID: "mock-id-1",
+					ParentTask2ID: "mock-id-2",
+				},
+			},
+		},
+		{
+			name:      "single number",
+			args:      args{rpn: mustParse("42")},
+			want:      []types.Task{},
+			wantValue: 42,
+		},
+		{
+			name: "exact values of literals",
+			args: args{rpn: mustParse("0.1 + -0.2 + 0.3")},
+			want: []types.Task{
+				{
+					ID:        "mock-id-1",
+					Operation: "+",
+					Arg1:      0.1,
+					Arg2:      -0.2,
+					ExactArg1: "1/10",
+					ExactArg2: "-1/5",
+				},
+				{
+					ID:            "mock-id-2",
+					Operation:     "+",
+					ParentTask1ID: "mock-id-1",
+					Arg2:          0.3,
+					ExactArg2:     "3/10",
+				},
+			},
+		},
+		{
+			name:      "negated number in parentheses",
+			args:      args{rpn: mustParse("-(7)")},
+			want:      []types.Task{},
+			wantValue: -7,
+		},
 		{
 			name: "empty input",
 			args: args{rpn: []types.Token{}},
@@ -248,16 +1550,28 @@ func TestCalculator_Schedule(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := NewCalculator()
-			tasks := c.Schedule(tt.args.rpn)
+			plan := c.Schedule(tt.args.rpn)
+			tasks := plan.Tasks
 
 			if !assert.Equal(t, len(tt.want), len(tasks), "Schedule task count doesn't match") {
 				return
 			}
+			assert.Equal(t, tt.wantValue, plan.Value)
 
 			for i := range tasks {
 				assert.Equal(t, tt.want[i].Operation, tasks[i].Operation)
 				assert.Equal(t, tt.want[i].Arg1, tasks[i].Arg1)
 				assert.Equal(t, tt.want[i].Arg2, tasks[i].Arg2)
+				assert.Equal(t, tt.want[i].Args, tasks[i].Args)
+				if tt.want[i].ExactArg1 != "" || tt.want[i].ExactArg2 != "" {
+					assert.Equal(t, tt.want[i].ExactArg1, tasks[i].ExactArg1)
+					assert.Equal(t, tt.want[i].ExactArg2, tasks[i].ExactArg2)
+				}
+				if assert.Equal(t, len(tt.want[i].ParentTaskIDs), len(tasks[i].ParentTaskIDs)) {
+					for j := range tasks[i].ParentTaskIDs {
+						assert.Equal(t, tt.want[i].ParentTaskIDs[j] != "", tasks[i].ParentTaskIDs[j] != "")
+					}
+				}
 
 				if tt.want[i].ParentTask1ID != "" {
 					assert.NotEmpty(t, tasks[i].ParentTask1ID, "Expected parent task ID 1")
@@ -270,6 +1584,11 @@ func TestCalculator_Schedule(t *testing.T) {
 				} else {
 					assert.Empty(t, tasks[i].ParentTask2ID, "Unexpected parent task ID 2")
 				}
+				if tt.want[i].ParentTask1ID != "" && tt.want[i].ParentTask1ID == tt.want[i].ParentTask2ID {
+					assert.Equal(t, tasks[i].ParentTask1ID, tasks[i].ParentTask2ID, "Expected shared parent task")
+				}
+				assert.Equal(t, tt.want[i].ConditionTaskID != "", tasks[i].ConditionTaskID != "", "Condition task ID")
+				assert.Equal(t, tt.want[i].ConditionValue, tasks[i].ConditionValue, "Condition value")
 			}
 
 			if len(tasks) > 1 {
@@ -285,6 +1604,14 @@ func TestCalculator_Schedule(t *testing.T) {
 					if task.ParentTask2ID != "" {
 						assert.True(t, taskIDMap[task.ParentTask2ID], "Referenced parent task 2 doesn't exist")
 					}
+					for _, parentID := range task.ParentTaskIDs {
+						if parentID != "" {
+							assert.True(t, taskIDMap[parentID], "Referenced parent task doesn't exist")
+						}
+					}
+					if task.ConditionTaskID != "" {
+						assert.True(t, taskIDMap[task.ConditionTaskID], "Referenced condition task doesn't exist")
+					}
 				}
 			}
 		})
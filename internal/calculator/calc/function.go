@@ -0,0 +1,133 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
+)
+
+var (
+	// functionHead matches the head of a function definition up to "=", e.g. "vat(x) =".
+	functionHead = regexp.MustCompile(`^\s*([A-Za-z_]\w*)\s*\(([^()]*)\)\s*=`)
+	identifier   = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+)
+
+// ParseFunction parses the definition of a user-defined function, e.g. "vat(x) = x * 1.2",
+// and checks that its body can be expanded along with the other funcs.
+// The function replaces the one of funcs with the same name, if any, so it can't call itself even indirectly.
+// Returns an error wrapping types.ErrInvalidFunction if the name or parameters are malformed
+// or shadow built-in functions or constants,
+// *types.ParseError with the offset in the definition if the body is invalid,
+// and types.ErrFunctionInUse if the functions calling the replaced one can't be expanded with it.
+func (c *Calculator) ParseFunction(def string, funcs map[string]types.Function) (types.Function, error) {
+	m := functionHead.FindStringSubmatchIndex(def)
+	if m == nil || strings.HasPrefix(def[m[1]:], "=") {
+		return types.Function{}, fmt.Errorf(`%w: definition must look like "name(x, y) = body"`, types.ErrInvalidFunction)
+	}
+
+	body := strings.TrimLeft(def[m[1]:], " \t\r\n")
+	bodyOffset := len(def) - len(body)
+	f := types.Function{Name: def[m[2]:m[3]], Body: strings.TrimRight(body, " \t\r\n")}
+	if err := c.checkFunctionName(f.Name); err != nil {
+		return types.Function{}, err
+	}
+	if params := strings.TrimSpace(def[m[4]:m[5]]); params != "" {
+		for _, param := range strings.Split(params, ",") {
+			param = strings.TrimSpace(param)
+			if !identifier.MatchString(param) || c.isFunc(param) {
+				return types.Function{}, fmt.Errorf("%w: invalid parameter %q", types.ErrInvalidFunction, param)
+			}
+			// Constants in the bodies of the called funcs would be substituted with arguments otherwise
+			if _, ok := constants[param]; ok {
+				return types.Function{}, fmt.Errorf("%w: parameter %q is a constant", types.ErrInvalidFunction, param)
+			}
+			if slices.Contains(f.Params, param) {
+				return types.Function{}, fmt.Errorf("%w: duplicate parameter %q", types.ErrInvalidFunction, param)
+			}
+			f.Params = append(f.Params, param)
+		}
+	}
+
+	defined := maps.Clone(funcs)
+	if defined == nil {
+		defined = map[string]types.Function{}
+	}
+	defined[f.Name] = f
+	if _, err := c.parseBody(f, defined, []string{f.Name}, floatLiterals, newExpansion()); err != nil {
+		var parseErr *types.ParseError
+		if errors.As(err, &parseErr) && parseErr.Reason != types.ReasonEmptyInput {
+			parseErr.Offset += bodyOffset
+		}
+		return types.Function{}, err
+	}
+	if err := c.checkCallers(f.Name, funcs, defined); err != nil {
+		return types.Function{}, err
+	}
+	return f, nil
+}
+
+// CheckFunctionDeletion checks that the function named name can be deleted from funcs,
+// that is, none of the other funcs calls it directly or through other functions.
+// Returns an error wrapping types.ErrFunctionInUse otherwise.
+func (c *Calculator) CheckFunctionDeletion(name string, funcs map[string]types.Function) error {
+	remaining := maps.Clone(funcs)
+	delete(remaining, name)
+	return c.checkCallers(name, funcs, remaining)
+}
+
+// UsedFunctions returns versions of the user-defined funcs the expression calls directly or through other functions.
+// The expression is expected to be valid, see Parse.
+func (c *Calculator) UsedFunctions(s string, vars map[string]float64, funcs map[string]types.Function) map[string]int {
+	used := map[string]int{}
+	var visit func(s string, vars map[string]float64)
+	visit = func(s string, vars map[string]float64) {
+		tokens, _ := c.tokenize(s, vars, funcs)
+		for _, t := range tokens {
+			f, ok := funcs[t.Symbol]
+			if t.IsNumber || !ok || c.isFunc(t.Symbol) {
+				continue
+			}
+			if _, ok := used[f.Name]; !ok {
+				used[f.Name] = f.Version
+				visit(f.Body, c.paramVars(f))
+			}
+		}
+	}
+	visit(s, vars)
+	return used
+}
+
+// checkCallers checks that the funcs calling the function named name, directly or through other functions,
+// can be expanded with the changed funcs, in which the function is redefined or deleted.
+func (c *Calculator) checkCallers(name string, funcs, changed map[string]types.Function) error {
+	for _, callerName := range slices.Sorted(maps.Keys(funcs)) {
+		caller := funcs[callerName]
+		if callerName == name {
+			continue
+		}
+		if _, ok := c.UsedFunctions(caller.Body, c.paramVars(caller), funcs)[name]; !ok {
+			continue
+		}
+		if _, err := c.parseBody(caller, changed, []string{callerName}, floatLiterals, newExpansion()); err != nil {
+			return fmt.Errorf("%w: in the body of %q: %s",
+				types.ErrFunctionInUse, callerName, strings.TrimPrefix(err.Error(), types.ErrInvalidExpr.Error()+": "))
+		}
+	}
+	return nil
+}
+
+// checkFunctionName checks that the name of a user-defined function doesn't shadow a built-in function or a constant.
+func (c *Calculator) checkFunctionName(name string) error {
+	if c.isFunc(name) {
+		return fmt.Errorf("%w: %q is a built-in function", types.ErrInvalidFunction, name)
+	}
+	if _, ok := constants[name]; ok {
+		return fmt.Errorf("%w: %q is a constant", types.ErrInvalidFunction, name)
+	}
+	return nil
+}
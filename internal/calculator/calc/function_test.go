@@ -0,0 +1,260 @@
+package calc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculator_ParseFunction(t *testing.T) {
+	errorIsErrInvalidFunction := func(s string) assert.ErrorAssertionFunc {
+		return func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+			return assert.ErrorIs(t, err, types.ErrInvalidFunction, msgAndArgs...) &&
+				assert.ErrorContains(t, err, s, msgAndArgs...)
+		}
+	}
+	errorIsParseError := func(want types.ParseError) assert.ErrorAssertionFunc {
+		return func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+			var got *types.ParseError
+			return assert.ErrorAs(t, err, &got, msgAndArgs...) && assert.Equal(t, want, *got, msgAndArgs...)
+		}
+	}
+	vat := types.Function{Name: "vat", Params: []string{"x"}, Body: "x * 1.2", Version: 1}
+
+	type args struct {
+		def   string
+		funcs map[string]types.Function
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    types.Function
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "function",
+			args:    args{def: " vat (x) =  x * 1.2 "},
+			want:    types.Function{Name: "vat", Params: []string{"x"}, Body: "x * 1.2"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "several parameters and other functions",
+			args:    args{def: "gross(x, fee) = vat(x) + fee", funcs: map[string]types.Function{"vat": vat}},
+			want:    types.Function{Name: "gross", Params: []string{"x", "fee"}, Body: "vat(x) + fee"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "no parameters",
+			args:    args{def: "answer() = 42"},
+			want:    types.Function{Name: "answer", Body: "42"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "comparison in the body",
+			args:    args{def: "positive(x) = x >= 0 == 1"},
+			want:    types.Function{Name: "positive", Params: []string{"x"}, Body: "x >= 0 == 1"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "malformed definition",
+			args:    args{def: "vat(x) == x * 1.2"},
+			wantErr: errorIsErrInvalidFunction(`definition must look like "name(x, y) = body"`),
+		},
+		{
+			name:    "missing parameter list",
+			args:    args{def: "vat = 1.2"},
+			wantErr: errorIsErrInvalidFunction(`definition must look like "name(x, y) = body"`),
+		},
+		{
+			name:    "built-in function name",
+			args:    args{def: "sqrt(x) = x ^ 0.5"},
+			wantErr: errorIsErrInvalidFunction(`"sqrt" is a built-in function`),
+		},
+		{
+			name:    "constant name",
+			args:    args{def: "pi() = 3"},
+			wantErr: errorIsErrInvalidFunction(`"pi" is a constant`),
+		},
+		{
+			name:    "invalid parameter",
+			args:    args{def: "f(x, 2y) = x"},
+			wantErr: errorIsErrInvalidFunction(`invalid parameter "2y"`),
+		},
+		{
+			name:    "constant parameter",
+			args:    args{def: "f(e) = g(1) + e", funcs: map[string]types.Function{"g": {Name: "g", Params: []string{"x"}, Body: "x * e"}}},
+			wantErr: errorIsErrInvalidFunction(`parameter "e" is a constant`),
+		},
+		{
+			name:    "duplicate parameter",
+			args:    args{def: "f(x, x) = x"},
+			wantErr: errorIsErrInvalidFunction(`duplicate parameter "x"`),
+		},
+		{
+			name:    "unknown identifier in the body",
+			args:    args{def: "tax(x) = x * rate"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 13, Token: "rate", Reason: types.ReasonUnknownIdentifier}),
+		},
+		{
+			name:    "empty body",
+			args:    args{def: "f(x) = "},
+			wantErr: errorIsParseError(types.ParseError{Reason: types.ReasonEmptyInput}),
+		},
+		{
+			name:    "recursive function",
+			args:    args{def: "f(x) = f(x - 1)"},
+			wantErr: errorIsParseError(types.ParseError{Offset: 7, Token: "f", Reason: types.ReasonInvalidFunctionCall, Detail: "recursive call"}),
+		},
+		{
+			name: "redefinition making functions mutually recursive",
+			args: args{
+				def:   "vat(x) = gross(x, 0)",
+				funcs: map[string]types.Function{"vat": vat, "gross": {Name: "gross", Params: []string{"x", "fee"}, Body: "vat(x) + fee"}},
+			},
+			wantErr: errorIsParseError(types.ParseError{
+				Offset: 9,
+				Token:  "gross",
+				Reason: types.ReasonInvalidFunctionCall,
+				Detail: `in the body of "gross": invalid function call "vat" at position 0: recursive call`,
+			}),
+		},
+		{
+			name: "redefinition keeping callers valid",
+			args: args{
+				def:   "vat(y) = y * 1.25",
+				funcs: map[string]types.Function{"vat": vat, "gross": {Name: "gross", Params: []string{"x", "fee"}, Body: "vat(x) + fee"}},
+			},
+			want:    types.Function{Name: "vat", Params: []string{"y"}, Body: "y * 1.25"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "redefinition breaking callers",
+			args: args{
+				def: "g(a, b) = a + b",
+				funcs: map[string]types.Function{
+					"g": {Name: "g", Params: []string{"x"}, Body: "x + 1"},
+					"f": {Name: "f", Params: []string{"y"}, Body: "g(y) * 2"},
+					"h": {Name: "h", Params: []string{"z"}, Body: "f(z) - 1"},
+				},
+			},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrFunctionInUse, msgAndArgs...) &&
+					assert.EqualError(t, err,
+						`invalid function: used by other functions: in the body of "f": invalid function call "g" at position 0`, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCalculator()
+			got, err := c.ParseFunction(tt.args.def, tt.args.funcs)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCalculator_CheckFunctionDeletion(t *testing.T) {
+	funcs := map[string]types.Function{
+		"vat":   {Name: "vat", Params: []string{"x"}, Body: "x * 1.2"},
+		"gross": {Name: "gross", Params: []string{"x", "fee"}, Body: "vat(x) + fee"},
+		"total": {Name: "total", Params: []string{"x"}, Body: "gross(x, 5) * 2"},
+	}
+
+	tests := []struct {
+		name    string
+		del     string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "function without callers",
+			del:     "total",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "function called directly and through other functions",
+			del:  "vat",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrFunctionInUse, msgAndArgs...) &&
+					assert.ErrorContains(t, err, `in the body of "gross"`, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCalculator()
+			tt.wantErr(t, c.CheckFunctionDeletion(tt.del, funcs))
+		})
+	}
+}
+
+func TestCalculator_UsedFunctions(t *testing.T) {
+	funcs := map[string]types.Function{
+		"vat":   {Name: "vat", Params: []string{"x"}, Body: "x * 1.2", Version: 3},
+		"gross": {Name: "gross", Params: []string{"x", "fee"}, Body: "vat(x) + fee", Version: 1},
+		"net":   {Name: "net", Params: []string{"x"}, Body: "x / 1.2", Version: 2},
+	}
+
+	type args struct {
+		s    string
+		vars map[string]float64
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]int
+	}{
+		{
+			name: "called directly and through other functions",
+			args: args{s: "gross(price, 5) + sqrt(4)", vars: map[string]float64{"price": 100}},
+			want: map[string]int{"gross": 1, "vat": 3},
+		},
+		{
+			name: "variables shadow functions",
+			args: args{s: "net * 2", vars: map[string]float64{"net": 1}},
+			want: map[string]int{},
+		},
+		{
+			name: "no functions",
+			args: args{s: "1 + 2"},
+			want: map[string]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCalculator()
+			assert.Equal(t, tt.want, c.UsedFunctions(tt.args.s, tt.args.vars, funcs))
+		})
+	}
+}
+
+func TestCalculator_Parse_expansionLimit(t *testing.T) {
+	// Every function of the chain doubles the size of the previous one
+	funcs := map[string]types.Function{"f0": {Name: "f0", Params: []string{"x"}, Body: "x + 1"}}
+	for i := 1; i <= 30; i++ {
+		name := fmt.Sprintf("f%d", i)
+		funcs[name] = types.Function{Name: name, Params: []string{"x"}, Body: fmt.Sprintf("f%d(f%d(x))", i-1, i-1)}
+	}
+	errorIsExpansionLimit := func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+		var parseErr *types.ParseError
+		return assert.ErrorAs(t, err, &parseErr, msgAndArgs...) &&
+			assert.Equal(t, types.ReasonInvalidFunctionCall, parseErr.Reason, msgAndArgs...) &&
+			assert.ErrorContains(t, err, "expands to more than", msgAndArgs...)
+	}
+
+	c := NewCalculator()
+
+	rpn, err := c.Parse("f10(1)", nil, funcs)
+	assert.NoError(t, err)
+	assert.Len(t, rpn, 1<<11+1)
+
+	_, err = c.Parse("f30(1)", nil, funcs)
+	errorIsExpansionLimit(t, err)
+
+	_, err = c.ParseFunction("f31(x) = f30(f30(x))", funcs)
+	errorIsExpansionLimit(t, err)
+}
@@ -0,0 +1,402 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
+)
+
+// maxExpandedNodes limits the size of the AST the calls of the user-defined functions expand to.
+// Nested calls multiply it, e.g. every function of the chain "f1(x) = f0(f0(x))", "f2(x) = f1(f1(x))", ...
+// doubles the size of the previous one.
+const maxExpandedNodes = 1 << 16
+
+// parser builds the AST from tokens using precedence climbing (Pratt parsing).
+type parser struct {
+	c      *Calculator
+	tokens []lexeme
+	pos    int
+
+	// funcs are the user-defined functions, whose calls are expanded inline
+	funcs map[string]types.Function
+	// expanding are the names of the user-defined functions whose bodies are being parsed, to detect recursion
+	expanding []string
+	// literals are the numbers allowed in the bodies of funcs, see Calculator.ParseInt and Calculator.ParseExact
+	literals literals
+	// expansion is shared by the parsers of the function bodies of the same expression
+	expansion *expansion
+
+	// groups are the brackets opened so far, which are either function calls or just groupings
+	groups []group
+}
+
+type group struct {
+	Open   lexeme
+	IsCall bool
+}
+
+// expansion tracks the calls of the user-defined functions expanded while parsing an expression.
+type expansion struct {
+	bodies map[string]types.Node // parsed bodies of the funcs, reused by every call
+	nodes  int                   // number of nodes the calls have expanded to so far
+}
+
+func newExpansion() *expansion {
+	return &expansion{bodies: map[string]types.Node{}}
+}
+
+// parse builds the AST of the whole expression, expanding calls of the user-defined funcs.
+// Returns *types.ParseError pointing to the offending token if the expression is malformed.
+func (c *Calculator) parse(tokens []lexeme, funcs map[string]types.Function, literals literals) (types.Node, error) {
+	p := &parser{c: c, tokens: tokens, funcs: funcs, literals: literals, expansion: newExpansion()}
+	return p.parseAll()
+}
+
+// parseBody builds the AST of the body of the user-defined function, where the parameters are number nodes
+// named after them, see substitute.
+func (c *Calculator) parseBody(
+	f types.Function,
+	funcs map[string]types.Function,
+	expanding []string,
+	literals literals,
+	exp *expansion,
+) (types.Node, error) {
+	tokens, err := c.tokenize(f.Body, c.paramVars(f), funcs)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkLiterals(tokens, literals); err != nil {
+		return nil, err
+	}
+	p := &parser{c: c, tokens: tokens, funcs: funcs, expanding: expanding, literals: literals, expansion: exp}
+	return p.parseAll()
+}
+
+// paramVars returns the parameters of the function as variables, so the tokenizer resolves them.
+func (c *Calculator) paramVars(f types.Function) map[string]float64 {
+	vars := make(map[string]float64, len(f.Params))
+	for _, param := range f.Params {
+		vars[param] = 0
+	}
+	return vars
+}
+
+// parseAll parses all tokens as a single expression.
+func (p *parser) parseAll() (types.Node, error) {
+	if len(p.tokens) == 0 {
+		return nil, &types.ParseError{Reason: types.ReasonEmptyInput}
+	}
+
+	node, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := p.peek(); ok {
+		return nil, p.unexpectedAfterOperand(t)
+	}
+	return node, nil
+}
+
+// parseExpr parses an expression of operators with precedence of at least minPrec.
+func (p *parser) parseExpr(minPrec int) (types.Node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t, ok := p.peek()
+		if !ok || !p.c.isBinaryOp(t.Symbol) || p.c.precedence(t.Symbol) < minPrec {
+			return left, nil
+		}
+		p.pos++
+
+		// Operators of the same precedence are taken by the right operand only if they are right-associative
+		nextMinPrec := p.c.precedence(t.Symbol) + 1
+		if p.c.isRightAssoc(t.Symbol) {
+			nextMinPrec = p.c.precedence(t.Symbol)
+		}
+		right, err := p.parseExpr(nextMinPrec)
+		if err != nil {
+			return nil, err
+		}
+		left = &types.BinaryNode{Op: t.Symbol, Left: left, Right: right}
+	}
+}
+
+// parseOperand parses a number, a prefix operation, a function call or an expression in brackets.
+func (p *parser) parseOperand() (types.Node, error) {
+	t, ok := p.peek()
+	if !ok {
+		prev := p.tokens[p.pos-1]
+		return nil, p.fail(p.missingOperandReason(prev), prev)
+	}
+
+	switch {
+	case t.IsNumber:
+		p.pos++
+		return &types.NumberNode{Value: t.Number, Name: t.Name, Exact: t.Exact}, nil
+	case t.Symbol == "pos":
+		p.pos++
+		return p.parseExpr(p.c.precedence(t.Symbol)) // "+x" is just x
+	case p.c.isUnaryOp(t.Symbol):
+		p.pos++
+		operand, err := p.parseExpr(p.c.precedence(t.Symbol))
+		if err != nil {
+			return nil, err
+		}
+		return &types.UnaryNode{Op: t.Symbol, Operand: operand}, nil
+	case p.isFunc(t.Symbol):
+		return p.parseCall()
+	case p.c.isOpeningBracket(t.Symbol):
+		p.pos++
+		p.groups = append(p.groups, group{Open: t})
+		node, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.closeGroup(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case p.c.isClosingBracket(t.Symbol):
+		if err := p.checkClosingBracket(t); err != nil {
+			return nil, err
+		}
+		prev := p.tokens[p.pos-1]
+		if p.c.isOpeningBracket(prev.Symbol) {
+			return nil, p.fail(types.ReasonUnexpectedToken, t) // empty brackets
+		}
+		return nil, p.fail(p.missingOperandReason(prev), prev)
+	case t.Symbol == ",":
+		if len(p.groups) == 0 || !p.groups[len(p.groups)-1].IsCall {
+			return nil, p.fail(types.ReasonUnexpectedToken, t)
+		}
+		return nil, p.fail(types.ReasonInvalidFunctionCall, t) // empty argument
+	default:
+		return nil, p.fail(types.ReasonDanglingOperator, t)
+	}
+}
+
+// parseCall parses a function call with comma-separated arguments in parentheses.
+func (p *parser) parseCall() (types.Node, error) {
+	fn := p.tokens[p.pos]
+	p.pos++
+	open, ok := p.peek()
+	if !ok || open.Symbol != "(" {
+		return nil, p.fail(types.ReasonInvalidFunctionCall, fn) // function name without arguments
+	}
+	p.pos++
+	p.groups = append(p.groups, group{Open: open, IsCall: true})
+
+	call := &types.CallNode{Func: fn.Symbol}
+	if t, ok := p.peek(); !ok || t.Symbol != ")" {
+		for {
+			arg, err := p.parseExpr(0)
+			if err != nil {
+				return nil, err
+			}
+			call.Args = append(call.Args, arg)
+			if t, ok := p.peek(); !ok || t.Symbol != "," {
+				break
+			}
+			p.pos++
+		}
+	}
+	if err := p.closeGroup(); err != nil {
+		return nil, err
+	}
+
+	if f, ok := p.funcs[fn.Symbol]; ok && !p.c.isFunc(fn.Symbol) {
+		if len(call.Args) != len(f.Params) {
+			return nil, p.fail(types.ReasonInvalidFunctionCall, fn)
+		}
+		return p.expand(fn, f, call.Args)
+	}
+	if f := functions[fn.Symbol]; len(call.Args) < f.minArgs || f.maxArgs != -1 && len(call.Args) > f.maxArgs {
+		return nil, p.fail(types.ReasonInvalidFunctionCall, fn)
+	}
+	return call, nil
+}
+
+// expand returns the body of the user-defined function with the parameters substituted by the arguments.
+// Errors in the body are reported at the call, as well as calls expanding to more than maxExpandedNodes nodes.
+func (p *parser) expand(fn lexeme, f types.Function, args []types.Node) (types.Node, error) {
+	if slices.Contains(p.expanding, f.Name) {
+		return nil, &types.ParseError{
+			Offset: fn.Offset,
+			Token:  fn.Text,
+			Reason: types.ReasonInvalidFunctionCall,
+			Detail: "recursive call",
+		}
+	}
+
+	body, ok := p.expansion.bodies[f.Name]
+	if !ok {
+		var err error
+		body, err = p.parseCalledBody(fn, f)
+		if err != nil {
+			return nil, err
+		}
+		p.expansion.bodies[f.Name] = body
+	}
+
+	params := make(map[string]types.Node, len(f.Params))
+	for i, param := range f.Params {
+		params[param] = args[i]
+	}
+	expanded := p.c.substitute(body, params)
+
+	p.expansion.nodes += countNodes(expanded, maxExpandedNodes-p.expansion.nodes)
+	if p.expansion.nodes > maxExpandedNodes {
+		return nil, &types.ParseError{
+			Offset: fn.Offset,
+			Token:  fn.Text,
+			Reason: types.ReasonInvalidFunctionCall,
+			Detail: fmt.Sprintf("expands to more than %d operations and operands", maxExpandedNodes),
+		}
+	}
+	return expanded, nil
+}
+
+// parseCalledBody parses the body of the function called by fn, errors in the body are reported at the call.
+// Successfully parsed bodies have no recursive calls, so they are reused regardless of the calls they are expanded in.
+func (p *parser) parseCalledBody(fn lexeme, f types.Function) (types.Node, error) {
+	body, err := p.c.parseBody(f, p.funcs, append(slices.Clone(p.expanding), f.Name), p.literals, p.expansion)
+	if err != nil {
+		var parseErr *types.ParseError
+		if !errors.As(err, &parseErr) {
+			return nil, err
+		}
+		return nil, &types.ParseError{
+			Offset: fn.Offset,
+			Token:  fn.Text,
+			Reason: types.ReasonInvalidFunctionCall,
+			Detail: fmt.Sprintf("in the body of %q: %s", f.Name, strings.TrimPrefix(err.Error(), types.ErrInvalidExpr.Error()+": ")),
+		}
+	}
+	return body, nil
+}
+
+// substitute replaces the number nodes named after the parameters with the argument nodes.
+func (c *Calculator) substitute(node types.Node, params map[string]types.Node) types.Node {
+	switch n := node.(type) {
+	case *types.NumberNode:
+		if arg, ok := params[n.Name]; ok && n.Name != "" {
+			return arg
+		}
+		return n
+	case *types.UnaryNode:
+		return &types.UnaryNode{Op: n.Op, Operand: c.substitute(n.Operand, params)}
+	case *types.BinaryNode:
+		return &types.BinaryNode{Op: n.Op, Left: c.substitute(n.Left, params), Right: c.substitute(n.Right, params)}
+	case *types.CallNode:
+		args := make([]types.Node, len(n.Args))
+		for i, arg := range n.Args {
+			args[i] = c.substitute(arg, params)
+		}
+		return &types.CallNode{Func: n.Func, Args: args}
+	default:
+		return node
+	}
+}
+
+// countNodes returns the number of nodes in the tree, or a number over limit if there are more.
+func countNodes(node types.Node, limit int) int {
+	var children []types.Node
+	switch n := node.(type) {
+	case *types.UnaryNode:
+		children = []types.Node{n.Operand}
+	case *types.BinaryNode:
+		children = []types.Node{n.Left, n.Right}
+	case *types.CallNode:
+		children = n.Args
+	}
+
+	count := 1
+	for _, child := range children {
+		if count > limit {
+			break
+		}
+		count += countNodes(child, limit-count)
+	}
+	return count
+}
+
+// closeGroup reads the bracket closing the innermost group.
+func (p *parser) closeGroup() error {
+	t, ok := p.peek()
+	if !ok {
+		// The leftmost unclosed bracket is reported, as the inner ones are matched greedily
+		return p.fail(types.ReasonUnbalancedParen, p.groups[0].Open)
+	}
+	if !p.c.isClosingBracket(t.Symbol) {
+		return p.unexpectedAfterOperand(t)
+	}
+	if err := p.checkClosingBracket(t); err != nil {
+		return err
+	}
+	p.pos++
+	p.groups = p.groups[:len(p.groups)-1]
+	return nil
+}
+
+// checkClosingBracket checks that the bracket closes the innermost group.
+func (p *parser) checkClosingBracket(t lexeme) error {
+	if len(p.groups) == 0 {
+		return p.fail(types.ReasonUnbalancedParen, t)
+	}
+	if g := p.groups[len(p.groups)-1]; g.Open.Symbol != brackets[t.Symbol] {
+		return &types.ParseError{
+			Offset: t.Offset,
+			Token:  t.Text,
+			Reason: types.ReasonUnbalancedParen,
+			Detail: fmt.Sprintf("%q at position %d is closed by %q", g.Open.Text, g.Open.Offset, t.Text),
+		}
+	}
+	return nil
+}
+
+// unexpectedAfterOperand explains why the token can't follow a complete operand.
+func (p *parser) unexpectedAfterOperand(t lexeme) error {
+	switch {
+	case p.c.isClosingBracket(t.Symbol):
+		return p.checkClosingBracket(t)
+	case t.Symbol == ",":
+		return p.fail(types.ReasonUnexpectedToken, t)
+	default:
+		return p.fail(types.ReasonMissingOperator, t)
+	}
+}
+
+// missingOperandReason explains why an operand is missing after the token.
+func (p *parser) missingOperandReason(t lexeme) types.ParseErrorReason {
+	switch {
+	case p.c.isOpeningBracket(t.Symbol):
+		return types.ReasonUnbalancedParen
+	case t.Symbol == ",":
+		return types.ReasonInvalidFunctionCall
+	default:
+		return types.ReasonDanglingOperator
+	}
+}
+
+// isFunc reports whether s is a built-in or a user-defined function.
+func (p *parser) isFunc(s string) bool {
+	_, ok := p.funcs[s]
+	return ok || p.c.isFunc(s)
+}
+
+func (p *parser) peek() (lexeme, bool) {
+	if p.pos == len(p.tokens) {
+		return lexeme{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) fail(reason types.ParseErrorReason, t lexeme) error {
+	return &types.ParseError{Offset: t.Offset, Token: t.Text, Reason: reason}
+}
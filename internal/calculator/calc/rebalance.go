@@ -0,0 +1,78 @@
+package calc
+
+import (
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
+)
+
+// Rebalance reassociates chains of additions, subtractions and multiplications into balanced trees,
+// so the depth of the task graph is O(log n) instead of O(n) and more tasks can be calculated in parallel:
+// "1+2+3+4" becomes "(1+2)+(3+4)" and "a-b-c-d" becomes "a-(b+(c+d))".
+// The result is mathematically equal, but may differ in floating-point rounding.
+func (c *Calculator) Rebalance(rpn []types.Token) []types.Token {
+	return c.toRPN(c.rebalance(c.fromRPN(rpn)))
+}
+
+func (c *Calculator) rebalance(node types.Node) types.Node {
+	switch n := node.(type) {
+	case *types.UnaryNode:
+		return &types.UnaryNode{Op: n.Op, Operand: c.rebalance(n.Operand)}
+	case *types.CallNode:
+		args := make([]types.Node, len(n.Args))
+		for i, arg := range n.Args {
+			args[i] = c.rebalance(arg)
+		}
+		return &types.CallNode{Func: n.Func, Args: args}
+	case *types.BinaryNode:
+		switch n.Op {
+		case "+", "-":
+			var added, subtracted []types.Node
+			c.collectTerms(n, false, &added, &subtracted)
+			if len(subtracted) == 0 {
+				return c.balance("+", added)
+			}
+			return &types.BinaryNode{Op: "-", Left: c.balance("+", added), Right: c.balance("+", subtracted)}
+		case "*":
+			var factors []types.Node
+			c.collectFactors(n, &factors)
+			return c.balance("*", factors)
+		default:
+			return &types.BinaryNode{Op: n.Op, Left: c.rebalance(n.Left), Right: c.rebalance(n.Right)}
+		}
+	default:
+		return node
+	}
+}
+
+// collectTerms flattens a chain of additions and subtractions into the terms to add and to subtract.
+// The terms are rebalanced themselves and keep their order.
+func (c *Calculator) collectTerms(node types.Node, negative bool, added, subtracted *[]types.Node) {
+	if n, ok := node.(*types.BinaryNode); ok && (n.Op == "+" || n.Op == "-") {
+		c.collectTerms(n.Left, negative, added, subtracted)
+		c.collectTerms(n.Right, negative != (n.Op == "-"), added, subtracted)
+		return
+	}
+	if negative {
+		*subtracted = append(*subtracted, c.rebalance(node))
+	} else {
+		*added = append(*added, c.rebalance(node))
+	}
+}
+
+// collectFactors flattens a chain of multiplications into its rebalanced factors, keeping their order.
+func (c *Calculator) collectFactors(node types.Node, factors *[]types.Node) {
+	if n, ok := node.(*types.BinaryNode); ok && n.Op == "*" {
+		c.collectFactors(n.Left, factors)
+		c.collectFactors(n.Right, factors)
+		return
+	}
+	*factors = append(*factors, c.rebalance(node))
+}
+
+// balance joins the operands with the associative operator into a tree of minimal depth.
+func (c *Calculator) balance(op string, operands []types.Node) types.Node {
+	if len(operands) == 1 {
+		return operands[0]
+	}
+	mid := len(operands) / 2
+	return &types.BinaryNode{Op: op, Left: c.balance(op, operands[:mid]), Right: c.balance(op, operands[mid:])}
+}
@@ -0,0 +1,71 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculator_Rebalance(t *testing.T) {
+	mustParse := func(s string) []types.Token {
+		return lo.Must(NewCalculator().Parse(s, nil, nil))
+	}
+
+	type args struct {
+		rpn []types.Token
+	}
+	tests := []struct {
+		name string
+		args args
+		want []types.Token
+	}{
+		{
+			name: "chain of additions",
+			args: args{rpn: mustParse("1+2+3+4+5+6+7+8")},
+			want: mustParse("((1+2)+(3+4))+((5+6)+(7+8))"),
+		},
+		{
+			name: "chain of multiplications",
+			args: args{rpn: mustParse("1*2*3*4*5")},
+			want: mustParse("(1*2)*(3*(4*5))"),
+		},
+		{
+			name: "chain of subtractions",
+			args: args{rpn: mustParse("1-2-3-4")},
+			want: mustParse("1-(2+(3+4))"),
+		},
+		{
+			name: "mixed additions and subtractions",
+			args: args{rpn: mustParse("1-2+3-(4-5)")},
+			want: mustParse("(1+(3+5))-(2+4)"),
+		},
+		{
+			name: "nested chains",
+			args: args{rpn: mustParse("max(1+2+3+4, 5) * 6 * 7 * (8+9+10)")},
+			want: mustParse("(max((1+2)+(3+4), 5) * 6) * (7 * (8+(9+10)))"),
+		},
+		{
+			name: "non-associative operators are kept",
+			args: args{rpn: mustParse("1/2/3/4 ^ 5 ^ 6")},
+			want: mustParse("1/2/3/4 ^ 5 ^ 6"),
+		},
+		{
+			name: "chain is broken by other operators",
+			args: args{rpn: mustParse("1+2+3*4/5*6")},
+			want: mustParse("1+(2+(3*4/5*6))"),
+		},
+		{
+			name: "single number",
+			args: args{rpn: mustParse("42")},
+			want: mustParse("42"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCalculator()
+			assert.Equal(t, tt.want, c.Rebalance(tt.args.rpn))
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package calc
+
+import (
+	"math"
+
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
+)
+
+// Simplify folds operations over literals and eliminates identities ("x*1", "x+0", "x-0", "x/1", "x*0")
+// so fewer tasks are sent to agents, "if" with a literal condition is replaced by the taken branch.
+// Returns the simplified expression and the number of tasks saved.
+// Constants and variables are treated as unknowns, only number literals are folded.
+// Folding is done in float64, so the exact values of the folded literals are lost.
+// Operations whose result is NaN or infinite, e.g. "1/0", are kept, so the expression fails as before.
+// Note that "x*0" becomes 0 even if x fails.
+func (c *Calculator) Simplify(rpn []types.Token) ([]types.Token, int) {
+	node := c.fromRPN(rpn)
+	simplified := c.simplify(node)
+	// Counting scheduled tasks accounts for identical subtrees sharing a single task
+	return c.toRPN(simplified), len(c.ScheduleAST(node).Tasks) - len(c.ScheduleAST(simplified).Tasks)
+}
+
+func (c *Calculator) simplify(node types.Node) types.Node {
+	switch n := node.(type) {
+	case *types.UnaryNode:
+		operand := c.simplify(n.Operand)
+		if values, ok := c.literals(operand); ok {
+			if n.Op == "neg" {
+				return &types.NumberNode{Value: -values[0], Exact: negateExact(operand.(*types.NumberNode).Exact)}
+			}
+			v := c.evaluate(n.Op, values...)
+			return &types.NumberNode{Value: v, Exact: types.ExactFloat(v)}
+		}
+		return &types.UnaryNode{Op: n.Op, Operand: operand}
+	case *types.BinaryNode:
+		left, right := c.simplify(n.Left), c.simplify(n.Right)
+		if values, ok := c.literals(left, right); ok {
+			if v := c.evaluate(n.Op, values...); !math.IsNaN(v) && !math.IsInf(v, 0) {
+				return &types.NumberNode{Value: v, Exact: types.ExactFloat(v)}
+			}
+		}
+		if id := c.eliminateIdentity(n.Op, left, right); id != nil {
+			return id
+		}
+		return &types.BinaryNode{Op: n.Op, Left: left, Right: right}
+	case *types.CallNode:
+		args := make([]types.Node, len(n.Args))
+		for i, arg := range n.Args {
+			args[i] = c.simplify(arg)
+		}
+		if values, ok := c.literals(args[0]); ok && n.Func == "if" {
+			if values[0] != 0 {
+				return args[1]
+			}
+			return args[2]
+		}
+		if values, ok := c.literals(args...); ok {
+			if v := c.evaluate(n.Func, values...); !math.IsNaN(v) && !math.IsInf(v, 0) {
+				return &types.NumberNode{Value: v, Exact: types.ExactFloat(v)}
+			}
+		}
+		return &types.CallNode{Func: n.Func, Args: args}
+	default:
+		return node
+	}
+}
+
+// eliminateIdentity returns the result of the operation if it doesn't depend on the operation being executed,
+// or nil otherwise.
+func (c *Calculator) eliminateIdentity(op string, left, right types.Node) types.Node {
+	isLiteral := func(node types.Node, v float64) bool {
+		values, ok := c.literals(node)
+		return ok && values[0] == v
+	}
+
+	switch {
+	case op == "*" && (isLiteral(left, 0) || isLiteral(right, 0)):
+		return &types.NumberNode{Value: 0, Exact: "0"}
+	case op == "*" && isLiteral(left, 1), op == "+" && isLiteral(left, 0):
+		return right
+	case op == "*" && isLiteral(right, 1), op == "/" && isLiteral(right, 1),
+		op == "+" && isLiteral(right, 0), op == "-" && isLiteral(right, 0):
+		return left
+	default:
+		return nil
+	}
+}
+
+// literals returns values of the nodes if all of them are number literals.
+func (c *Calculator) literals(nodes ...types.Node) ([]float64, bool) {
+	values := make([]float64, len(nodes))
+	for i, node := range nodes {
+		num, ok := node.(*types.NumberNode)
+		if !ok || num.Name != "" {
+			return nil, false
+		}
+		values[i] = num.Value
+	}
+	return values, true
+}
+
+// evaluate executes the operation the same way agents do. Returns NaN if the result is undefined.
+func (c *Calculator) evaluate(op string, args ...float64) float64 {
+	switch op {
+	case "+":
+		return args[0] + args[1]
+	case "-":
+		return args[0] - args[1]
+	case "*":
+		return args[0] * args[1]
+	case "/":
+		if args[1] == 0 {
+			return math.NaN()
+		}
+		return args[0] / args[1]
+	case "^":
+		return math.Pow(args[0], args[1])
+	case "%":
+		if args[1] == 0 {
+			return math.NaN()
+		}
+		res := math.Mod(args[0], args[1])
+		if res != 0 && (res < 0) != (args[1] < 0) {
+			res += args[1] // keep the sign of the divisor, consistent with the integer division
+		}
+		return res
+	case "//":
+		if args[1] == 0 {
+			return math.NaN()
+		}
+		return math.Floor(args[0] / args[1])
+	case "sqrt":
+		return math.Sqrt(args[0])
+	case "abs":
+		return math.Abs(args[0])
+	case "round":
+		return math.Round(args[0])
+	case "min":
+		res := args[0]
+		for _, arg := range args[1:] {
+			res = math.Min(res, arg)
+		}
+		return res
+	case "max":
+		res := args[0]
+		for _, arg := range args[1:] {
+			res = math.Max(res, arg)
+		}
+		return res
+	case "log":
+		if len(args) == 1 {
+			return math.Log(args[0])
+		}
+		if base := args[1]; base <= 0 || base == 1 {
+			return math.NaN()
+		}
+		return math.Log(args[0]) / math.Log(args[1])
+	case "<":
+		return boolToFloat(args[0] < args[1])
+	case "<=":
+		return boolToFloat(args[0] <= args[1])
+	case ">":
+		return boolToFloat(args[0] > args[1])
+	case ">=":
+		return boolToFloat(args[0] >= args[1])
+	case "==":
+		return boolToFloat(args[0] == args[1])
+	case "!=":
+		return boolToFloat(args[0] != args[1])
+	case "&&":
+		return boolToFloat(args[0] != 0 && args[1] != 0)
+	case "||":
+		return boolToFloat(args[0] != 0 || args[1] != 0)
+	case "not":
+		return boolToFloat(args[0] == 0)
+	default:
+		return math.NaN()
+	}
+}
+
+// boolToFloat returns 1 for true and 0 for false, the results of comparisons and logical operations.
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
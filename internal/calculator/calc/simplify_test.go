@@ -0,0 +1,107 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculator_Simplify(t *testing.T) {
+	mustParse := func(s string, vars map[string]float64) []types.Token {
+		return lo.Must(NewCalculator().Parse(s, vars, nil))
+	}
+	vars := map[string]float64{"x": 2}
+
+	type args struct {
+		rpn []types.Token
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      []types.Token
+		wantSaved int
+	}{
+		{
+			name:      "literal subtrees are folded",
+			args:      args{rpn: mustParse("(1+2)*3 + max(4, 2^3) - -5", nil)},
+			want:      mustParse("22", nil),
+			wantSaved: 6,
+		},
+		{
+			name:      "literal subtrees next to variables are folded",
+			args:      args{rpn: mustParse("x * (2+3) + sqrt(16)", vars)},
+			want:      mustParse("x * 5 + 4", vars),
+			wantSaved: 2,
+		},
+		{
+			name:      "identities are eliminated",
+			args:      args{rpn: mustParse("(x*1 + 0) - 0 + 1*(x/1) + (0+x)", vars)},
+			want:      mustParse("x + x + x", vars),
+			wantSaved: 6,
+		},
+		{
+			name:      "identical subtrees are counted once",
+			args:      args{rpn: mustParse("(x*1) + (x*1)", vars)},
+			want:      mustParse("x + x", vars),
+			wantSaved: 1,
+		},
+		{
+			name:      "multiplication by zero",
+			args:      args{rpn: mustParse("x + (x+x)*0", vars)},
+			want:      mustParse("x", vars),
+			wantSaved: 3,
+		},
+		{
+			name:      "identities are eliminated after folding",
+			args:      args{rpn: mustParse("x * (3-2) + (x - (1-1))", vars)},
+			want:      mustParse("x + x", vars),
+			wantSaved: 4,
+		},
+		{
+			name:      "undefined operations are kept",
+			args:      args{rpn: mustParse("1/0 + log(0) + 5%0 + 2^(3+1)", nil)},
+			want:      mustParse("1/0 + log(0) + 5%0 + 16", nil),
+			wantSaved: 2,
+		},
+		{
+			name:      "negation of variables is kept",
+			args:      args{rpn: mustParse("-x", vars)},
+			want:      mustParse("-x", vars),
+			wantSaved: 0,
+		},
+		{
+			name:      "comparisons are folded and the taken branch is kept",
+			args:      args{rpn: mustParse("if(2 > 1, x * 2, x + 1) + (1 < 2 && !0)", vars)},
+			want:      mustParse("x * 2 + 1", vars),
+			wantSaved: 6,
+		},
+		{
+			name:      "conditions on variables are kept",
+			args:      args{rpn: mustParse("if(x > 1, x, 0)", vars)},
+			want:      mustParse("if(x > 1, x, 0)", vars),
+			wantSaved: 0,
+		},
+		{
+			name:      "constants are not folded",
+			args:      args{rpn: mustParse("2 * pi * 1", nil)},
+			want:      mustParse("2 * pi", nil),
+			wantSaved: 1,
+		},
+		{
+			name:      "nothing to simplify",
+			args:      args{rpn: mustParse("x * x - x", vars)},
+			want:      mustParse("x * x - x", vars),
+			wantSaved: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCalculator()
+			got, saved := c.Simplify(tt.args.rpn)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantSaved, saved)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package types
+
+// Node is a node of the abstract syntax tree of an expression:
+// *NumberNode, *UnaryNode, *BinaryNode or *CallNode.
+type Node interface {
+	node()
+}
+
+// NumberNode is a number literal or a constant or a variable substituted with its value.
+type NumberNode struct {
+	Value float64
+	Name  string // name of the constant or the variable, empty for literals
+	Exact string // exact value, see Token.Exact
+}
+
+// UnaryNode is a prefix operation, e.g. "neg" for "-x".
+type UnaryNode struct {
+	Op      string
+	Operand Node
+}
+
+// BinaryNode is an infix operation, e.g. "+" or "^".
+type BinaryNode struct {
+	Op    string
+	Left  Node
+	Right Node
+}
+
+// CallNode is a function call.
+type CallNode struct {
+	Func string
+	Args []Node
+}
+
+func (*NumberNode) node() {}
+func (*UnaryNode) node()  {}
+func (*BinaryNode) node() {}
+func (*CallNode) node()   {}
@@ -2,22 +2,90 @@ package types
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
 )
 
-var ErrInvalidExpr = errors.New("invalid expression")
+var (
+	ErrInvalidExpr   = errors.New("invalid expression")
+	ErrInvalidNumber = fmt.Errorf("%w: invalid number", ErrInvalidExpr)
+
+	ErrInvalidVariable = errors.New("invalid variable")
+
+	ErrInvalidFunction = errors.New("invalid function")
+	// ErrFunctionInUse means that functions calling the redefined or deleted function can't be expanded anymore
+	ErrFunctionInUse = fmt.Errorf("%w: used by other functions", ErrInvalidFunction)
+)
+
+// ParseErrorReason describes why an expression can't be parsed.
+type ParseErrorReason string
+
+const (
+	ReasonEmptyInput          ParseErrorReason = "empty input"
+	ReasonUnknownCharacter    ParseErrorReason = "unknown character"
+	ReasonUnknownIdentifier   ParseErrorReason = "unknown identifier"
+	ReasonInvalidNumber       ParseErrorReason = "invalid number"
+	ReasonUnbalancedParen     ParseErrorReason = "unbalanced parenthesis"
+	ReasonDanglingOperator    ParseErrorReason = "dangling operator"
+	ReasonMissingOperator     ParseErrorReason = "missing operator"
+	ReasonInvalidFunctionCall ParseErrorReason = "invalid function call"
+	ReasonUnexpectedToken     ParseErrorReason = "unexpected token"
+)
+
+// ParseError describes an invalid expression and the location of the problem in it.
+// It wraps ErrInvalidExpr, or ErrInvalidNumber for ReasonInvalidNumber.
+type ParseError struct {
+	Offset int    // byte offset of the offending token in the expression
+	Token  string // offending token, empty for ReasonEmptyInput
+	Reason ParseErrorReason
+	Detail string // optional clarification of the reason, e.g. "multiple decimal points"
+}
+
+func (e *ParseError) Error() string {
+	msg := ErrInvalidExpr.Error() + ": " + string(e.Reason)
+	if e.Token != "" {
+		msg += fmt.Sprintf(" %q at position %d", e.Token, e.Offset)
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+func (e *ParseError) Unwrap() error {
+	if e.Reason == ReasonInvalidNumber {
+		return ErrInvalidNumber
+	}
+	return ErrInvalidExpr
+}
+
+// Function is a user-defined function, e.g. "vat(x) = x * 1.2", whose calls are expanded inline by the parser.
+// Its body may use only the parameters, constants and other functions.
+type Function struct {
+	Name    string
+	Params  []string
+	Body    string
+	Version int
+}
 
 type Token struct {
 	IsNumber bool
 	Number   float64
 	Symbol   string
+	Arity    int    // number of arguments of a function call
+	Name     string // name of a constant or a variable the number was substituted for
+	// Exact is the exact value of the number as a fraction "a/b" or an integer "a", see ExactFloat.
+	// Literals keep the value they are written with, e.g. "0.1" is "1/10" though Number is only close to it.
+	Exact string
 }
 
 func NewToken[T float64 | int | string](val T) Token {
 	switch v := any(val).(type) {
 	case float64:
-		return Token{IsNumber: true, Number: v}
+		return Token{IsNumber: true, Number: v, Exact: ExactFloat(v)}
 	case int:
-		return Token{IsNumber: true, Number: float64(v)}
+		return Token{IsNumber: true, Number: float64(v), Exact: strconv.Itoa(v)}
 	case string:
 		return Token{IsNumber: false, Symbol: v}
 	default:
@@ -25,6 +93,32 @@ func NewToken[T float64 | int | string](val T) Token {
 	}
 }
 
+// NewFuncToken creates a token of a function call with the given number of arguments.
+func NewFuncToken(name string, arity int) Token {
+	return Token{IsNumber: false, Symbol: name, Arity: arity}
+}
+
+// NewVarToken creates a number token substituted for the named constant or variable.
+func NewVarToken(name string, val float64) Token {
+	return Token{IsNumber: true, Number: val, Name: name, Exact: ExactFloat(val)}
+}
+
+// NewExactToken creates a number token of a literal, whose exact value may differ from the nearest float64.
+func NewExactToken(val float64, exact string) Token {
+	return Token{IsNumber: true, Number: val, Exact: exact}
+}
+
+// ExactFloat returns the exact value of the shortest decimal representation of the number,
+// e.g. "1/10" for 0.1 rather than the binary fraction the float64 actually holds.
+// Returns "" for NaN and infinities.
+func ExactFloat(v float64) string {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(v, 'g', -1, 64))
+	if !ok {
+		return ""
+	}
+	return r.RatString()
+}
+
 type Task struct {
 	ID            string
 	ParentTask1ID string
@@ -33,4 +127,29 @@ type Task struct {
 	Arg1      float64
 	Arg2      float64
 	Operation string
+
+	// Function tasks take any number of operands: ParentTaskIDs[i] is the task
+	// that produces Args[i] or "" if Args[i] is a literal. Arg1 and Arg2 aren't used by them.
+	ParentTaskIDs []string
+	Args          []float64
+
+	// ExactArg1, ExactArg2 and ExactArgs are exact values of the literal operands, see Token.Exact
+	ExactArg1 string
+	ExactArg2 string
+	ExactArgs []string
+
+	// Tasks of a branch of "if" are executed only if the result of ConditionTaskID,
+	// where any non-zero number is true, equals ConditionValue. The tasks of the other branch are never executed.
+	// ConditionTaskID is empty for unconditional tasks.
+	ConditionTaskID string
+	ConditionValue  bool
+}
+
+// Plan is the result of scheduling an expression.
+// If the expression reduces to a value without any operations (e.g. "42" or "-(7)"),
+// Tasks is empty and Value holds the result.
+type Plan struct {
+	Tasks []Task
+	Value float64
+	Exact string // exact Value, see Token.Exact
 }
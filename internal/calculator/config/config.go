@@ -13,26 +13,66 @@ type Config struct {
 	HTTPAddr     string `env:"HTTP_ADDR"`
 	DBBadgerPath string `env:"DB_BADGER_PATH"`
 
-	TimeAdditionMs       int `env:"TIME_ADDITION_MS"`
-	TimeSubtractionMs    int `env:"TIME_SUBTRACTION_MS"`
-	TimeMultiplicationMs int `env:"TIME_MULTIPLICATIONS_MS"`
-	TimeDivisionMs       int `env:"TIME_DIVISIONS_MS"`
+	TimeAdditionMs        int `env:"TIME_ADDITION_MS"`
+	TimeSubtractionMs     int `env:"TIME_SUBTRACTION_MS"`
+	TimeMultiplicationMs  int `env:"TIME_MULTIPLICATIONS_MS"`
+	TimeDivisionMs        int `env:"TIME_DIVISIONS_MS"`
+	TimeNegationMs        int `env:"TIME_NEGATION_MS"`
+	TimePowerMs           int `env:"TIME_POWER_MS"`
+	TimeModuloMs          int `env:"TIME_MODULO_MS"`
+	TimeIntegerDivisionMs int `env:"TIME_INTEGER_DIVISION_MS"`
+	TimeFunctionMs        int `env:"TIME_FUNCTION_MS"`
+	TimeComparisonMs      int `env:"TIME_COMPARISON_MS"`
+
+	TaskLeaseMs           int `env:"TASK_LEASE_MS"`            // agents extend leases of the tasks being processed
+	TaskMaxAttempts       int `env:"TASK_MAX_ATTEMPTS"`        // expired leases after which the expression fails
+	TaskReclaimIntervalMs int `env:"TASK_RECLAIM_INTERVAL_MS"` // how often expired leases are looked for
+
+	RebalanceExpressions bool `env:"REBALANCE_EXPRESSIONS"` // default for CalculateRequest.rebalance
+	SimplifyExpressions  bool `env:"SIMPLIFY_EXPRESSIONS"`  // default for CalculateRequest.simplify
 }
 
 func Load() (*Config, error) {
 	conf := &Config{
-		LogLevel:             "info",
-		MgmtAddr:             ":8081",
-		GRPCAddr:             ":50051",
-		HTTPAddr:             ":8080",
-		DBBadgerPath:         ".data/badger",
-		TimeAdditionMs:       1000,
-		TimeSubtractionMs:    1000,
-		TimeMultiplicationMs: 1000,
-		TimeDivisionMs:       1000,
+		LogLevel:              "info",
+		MgmtAddr:              ":8081",
+		GRPCAddr:              ":50051",
+		HTTPAddr:              ":8080",
+		DBBadgerPath:          ".data/badger",
+		TimeAdditionMs:        1000,
+		TimeSubtractionMs:     1000,
+		TimeMultiplicationMs:  1000,
+		TimeDivisionMs:        1000,
+		TimeNegationMs:        1000,
+		TimePowerMs:           1000,
+		TimeModuloMs:          1000,
+		TimeIntegerDivisionMs: 1000,
+		TimeFunctionMs:        1000,
+		TimeComparisonMs:      1000,
+		TaskLeaseMs:           30000,
+		TaskMaxAttempts:       3,
+		TaskReclaimIntervalMs: 10000,
+		RebalanceExpressions:  false,
+		SimplifyExpressions:   false,
 	}
 	if err := env.Parse(conf); err != nil {
 		return nil, fmt.Errorf("env parse: %w", err)
 	}
+	if err := conf.validate(); err != nil {
+		return nil, fmt.Errorf("validate: %w", err)
+	}
 	return conf, nil
 }
+
+func (c *Config) validate() error {
+	if c.TaskLeaseMs <= 0 {
+		return fmt.Errorf("TASK_LEASE_MS must be positive, got %d", c.TaskLeaseMs)
+	}
+	if c.TaskMaxAttempts < 1 {
+		return fmt.Errorf("TASK_MAX_ATTEMPTS must be at least 1, got %d", c.TaskMaxAttempts)
+	}
+	if c.TaskReclaimIntervalMs <= 0 {
+		return fmt.Errorf("TASK_RECLAIM_INTERVAL_MS must be positive, got %d", c.TaskReclaimIntervalMs)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/dgraph-io/badger/v4"
 )
@@ -49,6 +50,14 @@ func taskQueuePendingKey(id string) []byte {
 	return []byte("task:queue:pending:" + id)
 }
 
+func taskQueueInProgressPrefix() []byte {
+	return []byte("task:queue:in_progress:")
+}
+
+func taskQueueInProgressKey(id string) []byte {
+	return []byte("task:queue:in_progress:" + id)
+}
+
 func taskChildPrefix(id string) []byte {
 	return []byte("task:" + id + ":child:")
 }
@@ -57,6 +66,27 @@ func taskChildKey(id string, childID string) []byte {
 	return []byte("task:" + id + ":child:" + childID)
 }
 
+// Function key constructors
+func funcListKey(name string) []byte {
+	return []byte("func:list:" + name)
+}
+
+func funcListPrefix() []byte {
+	return []byte("func:list:")
+}
+
+func funcVersionKey(name string, version int) []byte {
+	return []byte("func:versions:" + name + ":" + strconv.Itoa(version))
+}
+
+func funcVersionPrefix(name string) []byte {
+	return []byte("func:versions:" + name + ":")
+}
+
+func funcRevisionKey() []byte {
+	return []byte("func:revision")
+}
+
 // ID extraction from keys
 func exprIDFromListKey(key []byte) string {
 	return string(key)[len("expr:list:"):]
@@ -70,6 +100,10 @@ func taskIDFromPendingQueueKey(key []byte) string {
 	return string(key)[len("task:queue:pending:"):]
 }
 
+func taskIDFromInProgressQueueKey(key []byte) string {
+	return string(key)[len("task:queue:in_progress:"):]
+}
+
 func taskIDFromExprFinalTaskKey(key []byte, exprID string) string {
 	return string(key)[len("expr:"+exprID+":final:"):]
 }
@@ -78,6 +112,15 @@ func taskIDFromTaskChildKey(key []byte, taskID string) string {
 	return string(key)[len("task:"+taskID+":child:"):]
 }
 
+func funcNameFromListKey(key []byte) string {
+	return string(key)[len("func:list:"):]
+}
+
+func funcVersionFromVersionKey(key []byte, name string) int {
+	version, _ := strconv.Atoi(string(key)[len("func:versions:"+name+":"):])
+	return version
+}
+
 // BadgerDB operation helpers
 func scanVal[T any](txn *badger.Txn, key []byte, dst *T) error {
 	item, err := txn.Get(key)
@@ -3,22 +3,52 @@ package models
 import "time"
 
 type CreateExpressionCmd struct {
-	Expression string
+	Expression  string
+	Result      float64 // used only for expressions without tasks
+	ExactResult string  // used only for expressions without tasks in ArithmeticExact and ArithmeticBigInt
+	Variables   map[string]float64
+	Arithmetic  Arithmetic
+	Functions   map[string]int // versions of the user-defined functions the expression called
 }
 
 type CreateExpressionTaskCmd struct {
 	ID            string
 	ParentTask1ID string
 	ParentTask2ID string
+	ParentTaskIDs []string
 
 	Arg1          float64
 	Arg2          float64
+	Args          []float64
 	Operation     TaskOperation
 	OperationTime time.Duration
+
+	// Exact values of the literal operands, used only in ArithmeticExact and ArithmeticBigInt
+	ExactArg1 string
+	ExactArg2 string
+	ExactArgs []string
+
+	// The task is executed only if the result of ConditionTaskID is true (non-zero) == ConditionValue
+	ConditionTaskID string
+	ConditionValue  bool
 }
 
 type FinishTaskCmd struct {
-	ID     string
-	Status TaskStatus
-	Result float64
+	ID          string
+	LeaseToken  string
+	Status      TaskStatus
+	Result      float64
+	ExactResult string // used only in ArithmeticExact and ArithmeticBigInt
+}
+
+type CreateFunctionCmd struct {
+	Name   string
+	Params []string
+	Body   string
+}
+
+// ReclaimedTasks counts in-progress tasks whose leases expired.
+type ReclaimedTasks struct {
+	Requeued int // returned to the pending queue
+	Failed   int // exceeded the maximum number of attempts and failed their expressions
 }
@@ -9,6 +9,10 @@ var (
 	ErrExpressionNotFound = errors.New("expression not found")
 	ErrTaskNotFound       = errors.New("task not found")
 	ErrNoPendingTasks     = errors.New("no pending tasks")
+	ErrTaskNotInProgress  = errors.New("task not in progress")
+	ErrLeaseTokenMismatch = errors.New("lease token mismatch")
+	ErrExpressionFinished = errors.New("expression already finished")
+	ErrFunctionNotFound   = errors.New("function not found")
 )
 
 type Expression struct {
@@ -17,6 +21,13 @@ type Expression struct {
 	Status     ExpressionStatus `json:"status"`
 	Result     float64          `json:"result"`
 	Error      string           `json:"error"`
+	// Variables are values of constants and variables the expression was calculated with
+	Variables map[string]float64 `json:"variables,omitempty"`
+	// Arithmetic is the number system of the expression, empty for expressions stored before it was introduced
+	Arithmetic  Arithmetic `json:"arithmetic,omitempty"`
+	ExactResult string     `json:"exact_result,omitempty"` // Result in ArithmeticExact and ArithmeticBigInt
+	// Functions are versions of the user-defined functions the expression called
+	Functions map[string]int `json:"functions,omitempty"`
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -29,6 +40,7 @@ const (
 	ExpressionStatusInProgress ExpressionStatus = "InProgress"
 	ExpressionStatusCompleted  ExpressionStatus = "Completed"
 	ExpressionStatusFailed     ExpressionStatus = "Failed"
+	ExpressionStatusCancelled  ExpressionStatus = "Cancelled"
 )
 
 type Task struct {
@@ -36,26 +48,92 @@ type Task struct {
 	ExpressionID  string `json:"expression_id"`
 	ParentTask1ID string `json:"parent_task_1_id"`
 	ParentTask2ID string `json:"parent_task_2_id"`
+	// ParentTaskIDs and Args are operands of function tasks, which don't use Arg1 and Arg2
+	ParentTaskIDs []string `json:"parent_task_ids,omitempty"`
 
 	Arg1          float64       `json:"arg_1"`
 	Arg2          float64       `json:"arg_2"`
+	Args          []float64     `json:"args,omitempty"`
 	Operation     TaskOperation `json:"operation"`
 	OperationTime time.Duration `json:"operation_time"`
 	Status        TaskStatus    `json:"status"`
 	Result        float64       `json:"result"`
-	ExpireAt      time.Time     `json:"expire_at"` // TODO: to think
+	ExpireAt      time.Time     `json:"expire_at"`             // end of the lease of the in-progress task, then it is requeued
+	Attempts      int           `json:"attempts,omitempty"`    // number of the expired leases of the task
+	LeaseToken    string        `json:"lease_token,omitempty"` // changes every time the task is handed to an agent
+
+	// Arithmetic is the number system of the task; Arg1, Arg2, Args and Result are approximations
+	// of the exact values in ArithmeticExact and ArithmeticBigInt
+	Arithmetic  Arithmetic `json:"arithmetic,omitempty"`
+	ExactArg1   string     `json:"exact_arg_1,omitempty"`
+	ExactArg2   string     `json:"exact_arg_2,omitempty"`
+	ExactArgs   []string   `json:"exact_args,omitempty"`
+	ExactResult string     `json:"exact_result,omitempty"`
+
+	// The task is executed only if the result of ConditionTaskID is true (non-zero) == ConditionValue,
+	// otherwise it is TaskStatusSkipped. ConditionTaskID is empty for unconditional tasks.
+	ConditionTaskID string `json:"condition_task_id,omitempty"`
+	ConditionValue  bool   `json:"condition_value,omitempty"`
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// ParentIDs returns identifiers of all tasks whose results the task depends on, including the condition task.
+func (t Task) ParentIDs() []string {
+	var ids []string
+	for _, id := range append([]string{t.ParentTask1ID, t.ParentTask2ID, t.ConditionTaskID}, t.ParentTaskIDs...) {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// IsTrue reports whether the result of the completed task is true as a condition, that is, non-zero.
+func (t Task) IsTrue() bool {
+	if t.ExactResult != "" {
+		return t.ExactResult != "0"
+	}
+	return t.Result != 0
+}
+
+// Arithmetic is the number system expressions and tasks are calculated in.
+type Arithmetic string
+
+const (
+	ArithmeticFloat  Arithmetic = "float"
+	ArithmeticExact  Arithmetic = "exact"   // rational numbers encoded as "a/b" or "a"
+	ArithmeticBigInt Arithmetic = "big_int" // arbitrary-precision integers encoded as "a"
+)
+
 type TaskOperation string
 
 const (
-	TaskOperationAddition       TaskOperation = "+"
-	TaskOperationSubtraction    TaskOperation = "-"
-	TaskOperationMultiplication TaskOperation = "*"
-	TaskOperationDivision       TaskOperation = "/"
+	TaskOperationAddition        TaskOperation = "+"
+	TaskOperationSubtraction     TaskOperation = "-"
+	TaskOperationMultiplication  TaskOperation = "*"
+	TaskOperationDivision        TaskOperation = "/"
+	TaskOperationNegation        TaskOperation = "neg"
+	TaskOperationPower           TaskOperation = "^"
+	TaskOperationModulo          TaskOperation = "%"
+	TaskOperationIntegerDivision TaskOperation = "//"
+	TaskOperationSqrt            TaskOperation = "sqrt"
+	TaskOperationAbs             TaskOperation = "abs"
+	TaskOperationMin             TaskOperation = "min"
+	TaskOperationMax             TaskOperation = "max"
+	TaskOperationRound           TaskOperation = "round"
+	TaskOperationLog             TaskOperation = "log"
+	TaskOperationLess            TaskOperation = "<"
+	TaskOperationLessOrEqual     TaskOperation = "<="
+	TaskOperationGreater         TaskOperation = ">"
+	TaskOperationGreaterOrEqual  TaskOperation = ">="
+	TaskOperationEqual           TaskOperation = "=="
+	TaskOperationNotEqual        TaskOperation = "!="
+	TaskOperationAnd             TaskOperation = "&&"
+	TaskOperationOr              TaskOperation = "||"
+	TaskOperationNot             TaskOperation = "not"
+	TaskOperationIf              TaskOperation = "if"
 )
 
 type TaskStatus string
@@ -65,4 +143,17 @@ const (
 	TaskStatusInProgress TaskStatus = "InProgress"
 	TaskStatusCompleted  TaskStatus = "Completed"
 	TaskStatusFailed     TaskStatus = "Failed"
+	TaskStatusSkipped    TaskStatus = "Skipped"   // in the untaken branch of a condition
+	TaskStatusCancelled  TaskStatus = "Cancelled" // unfinished when the expression was cancelled
 )
+
+// Function is a version of a user-defined function, e.g. "vat(x) = x * 1.2".
+// Every version is kept, so expressions can be traced to the definitions they were calculated with.
+type Function struct {
+	Name    string   `json:"name"`
+	Params  []string `json:"params"`
+	Body    string   `json:"body"`
+	Version int      `json:"version"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/repository/models"
@@ -11,6 +12,9 @@ import (
 	"github.com/rs/xid"
 )
 
+// maxTxnConflictRetries limits retries of transactions conflicting with concurrent ones.
+const maxTxnConflictRetries = 10
+
 // Repository provides storage operations for calculator expressions and tasks.
 type Repository struct {
 	db *badger.DB
@@ -23,6 +27,7 @@ func New(db *badger.DB) *Repository {
 
 // CreateExpression stores a new expression with its associated tasks
 // and returns the ID of the created expression.
+// An expression without tasks is stored as already completed with exprCmd.Result.
 func (r *Repository) CreateExpression(
 	_ context.Context,
 	exprCmd models.CreateExpressionCmd,
@@ -34,9 +39,17 @@ func (r *Repository) CreateExpression(
 		ID:         xid.New().String(),
 		Expression: exprCmd.Expression,
 		Status:     models.ExpressionStatusPending,
+		Variables:  exprCmd.Variables,
+		Arithmetic: exprCmd.Arithmetic,
+		Functions:  exprCmd.Functions,
 		CreatedAt:  timeNow,
 		UpdatedAt:  timeNow,
 	}
+	if len(tasksCmd) == 0 {
+		expr.Status = models.ExpressionStatusCompleted
+		expr.Result = exprCmd.Result
+		expr.ExactResult = exprCmd.ExactResult
+	}
 
 	tasks := make([]models.Task, 0, len(tasksCmd))
 	for _, t := range tasksCmd {
@@ -45,23 +58,34 @@ func (r *Repository) CreateExpression(
 			ExpressionID:  expr.ID,
 			ParentTask1ID: t.ParentTask1ID,
 			ParentTask2ID: t.ParentTask2ID,
+			ParentTaskIDs: t.ParentTaskIDs,
 			Arg1:          t.Arg1,
 			Arg2:          t.Arg2,
+			Args:          t.Args,
 			Operation:     t.Operation,
 			OperationTime: t.OperationTime,
 			Status:        models.TaskStatusPending,
-			CreatedAt:     timeNow,
-			UpdatedAt:     timeNow,
+			Arithmetic:    exprCmd.Arithmetic,
+			ExactArg1:     t.ExactArg1,
+			ExactArg2:     t.ExactArg2,
+			ExactArgs:     t.ExactArgs,
+
+			ConditionTaskID: t.ConditionTaskID,
+			ConditionValue:  t.ConditionValue,
+
+			CreatedAt: timeNow,
+			UpdatedAt: timeNow,
 		})
 	}
 
-	taskToChildTask := map[string]string{}
+	// A task may be shared by several children, and even be both operands of the same child.
+	// Condition tasks are parents of the tasks of their branches, so they are skipped or enqueued once it completes
+	taskToChildTasks := map[string][]string{}
 	for _, task := range tasks {
-		if task.ParentTask1ID != "" {
-			taskToChildTask[task.ParentTask1ID] = task.ID
-		}
-		if task.ParentTask2ID != "" {
-			taskToChildTask[task.ParentTask2ID] = task.ID
+		for _, parentID := range task.ParentIDs() {
+			if !slices.Contains(taskToChildTasks[parentID], task.ID) {
+				taskToChildTasks[parentID] = append(taskToChildTasks[parentID], task.ID)
+			}
 		}
 	}
 
@@ -81,10 +105,12 @@ func (r *Repository) CreateExpression(
 				return fmt.Errorf("add to expr's task list: %w", err)
 			}
 
-			// Set up task relationships - either mark as child task or final expression task
-			if childTaskID, ok := taskToChildTask[task.ID]; ok {
-				if err := setOnlyKey(txn, taskChildKey(task.ID, childTaskID)); err != nil {
-					return fmt.Errorf("set task's child task: %w", err)
+			// Set up task relationships - either link child tasks or mark as final expression task
+			if childTaskIDs, ok := taskToChildTasks[task.ID]; ok {
+				for _, childTaskID := range childTaskIDs {
+					if err := setOnlyKey(txn, taskChildKey(task.ID, childTaskID)); err != nil {
+						return fmt.Errorf("set task's child task: %w", err)
+					}
 				}
 			} else {
 				if err := setOnlyKey(txn, exprFinalTaskKey(expr.ID, task.ID)); err != nil {
@@ -93,7 +119,7 @@ func (r *Repository) CreateExpression(
 			}
 
 			// Add root tasks (no parents) to the pending queue for immediate processing
-			if task.ParentTask1ID == "" && task.ParentTask2ID == "" {
+			if len(task.ParentIDs()) == 0 {
 				if err := setOnlyKey(txn, taskQueuePendingKey(task.ID)); err != nil {
 					return fmt.Errorf("enque task: %w", err)
 				}
@@ -158,9 +184,46 @@ func (r *Repository) GetExpression(_ context.Context, id string) (models.Express
 	return expr, nil
 }
 
-// GetPendingTask retrieves and claims the first available pending task.
+// CancelExpression stops calculation of the expression: its unfinished tasks are removed from the queues
+// and marked as cancelled, so that their results are discarded. Cancelling a cancelled expression is a no-op.
+// Returns models.ErrExpressionNotFound if the expression doesn't exist
+// and models.ErrExpressionFinished if it has already been completed or failed.
+func (r *Repository) CancelExpression(_ context.Context, id string) (models.Expression, error) {
+	var expr models.Expression
+
+	err := r.updateRetryingConflicts(func(txn *badger.Txn) error {
+		if err := scanVal(txn, exprKey(id), &expr); err != nil {
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return models.ErrExpressionNotFound
+			}
+			return fmt.Errorf("get expr: %w", err)
+		}
+
+		switch expr.Status {
+		case models.ExpressionStatusCompleted, models.ExpressionStatusFailed:
+			return models.ErrExpressionFinished
+		case models.ExpressionStatusCancelled:
+			return nil
+		}
+
+		if err := r.stopExpression(txn, id, models.ExpressionStatusCancelled, models.TaskStatusCancelled); err != nil {
+			return fmt.Errorf("cancel expr: %w", err)
+		}
+		if err := scanVal(txn, exprKey(id), &expr); err != nil {
+			return fmt.Errorf("get cancelled expr: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return models.Expression{}, err
+	}
+	return expr, nil
+}
+
+// GetPendingTask retrieves and claims the first available pending task for the lease duration.
 // Returns models.ErrNoPendingTasks if there are no pending tasks available.
-func (r *Repository) GetPendingTask(_ context.Context) (models.Task, error) {
+func (r *Repository) GetPendingTask(_ context.Context, lease time.Duration) (models.Task, error) {
 	var task models.Task
 
 	err := r.db.Update(func(txn *badger.Txn) error {
@@ -189,10 +252,14 @@ func (r *Repository) GetPendingTask(_ context.Context) (models.Task, error) {
 		timeNow := time.Now().UTC()
 		task.Status = models.TaskStatusInProgress
 		task.UpdatedAt = timeNow
-		task.ExpireAt = timeNow.Add(2 * (task.OperationTime + time.Minute)) // TODO: to think
+		task.ExpireAt = timeNow.Add(lease)
+		task.LeaseToken = xid.New().String()
 		if err := setVal(txn, taskKey(taskID), task); err != nil {
 			return fmt.Errorf("to in-progress task: %w", err)
 		}
+		if err := setOnlyKey(txn, taskQueueInProgressKey(taskID)); err != nil {
+			return fmt.Errorf("add task to in-progress queue: %w", err)
+		}
 
 		// Update parent expression state if this is the first task being processed
 		var expr models.Expression
@@ -217,11 +284,50 @@ func (r *Repository) GetPendingTask(_ context.Context) (models.Task, error) {
 	return task, nil
 }
 
+// ExtendTaskLease extends the lease of the in-progress task for the lease duration from now
+// and returns the new expiration time.
+// Returns models.ErrTaskNotFound if the task doesn't exist, models.ErrTaskNotInProgress
+// if the task isn't in progress anymore, e.g. its lease has already expired,
+// and models.ErrLeaseTokenMismatch if the task has been handed to another agent since.
+func (r *Repository) ExtendTaskLease(_ context.Context, id, leaseToken string, lease time.Duration) (time.Time, error) {
+	var task models.Task
+
+	err := r.db.Update(func(txn *badger.Txn) error {
+		if err := scanVal(txn, taskKey(id), &task); err != nil {
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return models.ErrTaskNotFound
+			}
+			return fmt.Errorf("get task: %w", err)
+		}
+
+		if err := checkLease(task, leaseToken); err != nil {
+			return err
+		}
+
+		timeNow := time.Now().UTC()
+		task.UpdatedAt = timeNow
+		task.ExpireAt = timeNow.Add(lease)
+		if err := setVal(txn, taskKey(task.ID), task); err != nil {
+			return fmt.Errorf("update task: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return time.Time{}, err
+	}
+	return task.ExpireAt, nil
+}
+
 // FinishTask updates a task's status and result, and handles subsequent operations
 // like updating related tasks, enqueueing child tasks, or completing expressions.
-// Returns models.ErrTaskNotFound if the task doesn't exist.
+// Submitting the same result under the same lease again is a no-op, so agents can safely retry submissions.
+// Results of the tasks of cancelled expressions are discarded.
+// Returns models.ErrTaskNotFound if the task doesn't exist, models.ErrTaskNotInProgress
+// if the task has already been finished with another result or its lease has expired,
+// and models.ErrLeaseTokenMismatch if the task has been handed to another agent since.
 func (r *Repository) FinishTask(_ context.Context, cmd models.FinishTaskCmd) error {
-	return r.db.Update(func(txn *badger.Txn) error {
+	return r.updateRetryingConflicts(func(txn *badger.Txn) error {
 		if cmd.Status != models.TaskStatusCompleted && cmd.Status != models.TaskStatusFailed {
 			return fmt.Errorf("unexpected task status: %s", cmd.Status)
 		}
@@ -235,8 +341,23 @@ func (r *Repository) FinishTask(_ context.Context, cmd models.FinishTaskCmd) err
 			return fmt.Errorf("get task: %w", err)
 		}
 
+		if task.Status == models.TaskStatusCancelled {
+			return nil // the expression was cancelled, the result is discarded
+		}
+		if isSameResult(task, cmd) {
+			return nil // duplicate submission
+		}
+		if err := checkLease(task, cmd.LeaseToken); err != nil {
+			return err
+		}
+
+		if err := txn.Delete(taskQueueInProgressKey(task.ID)); err != nil {
+			return fmt.Errorf("delete task from in-progress queue: %w", err)
+		}
+
 		task.Status = cmd.Status
 		task.Result = cmd.Result
+		task.ExactResult = cmd.ExactResult
 		task.UpdatedAt = time.Now().UTC()
 		if err := setVal(txn, taskKey(task.ID), task); err != nil {
 			return fmt.Errorf("update task: %w", err)
@@ -250,15 +371,15 @@ func (r *Repository) FinishTask(_ context.Context, cmd models.FinishTaskCmd) err
 			return nil
 		}
 
-		// Process successfully completed task - either enqueue child or complete expression
+		// Process successfully completed task - either enqueue children or complete expression
 		isFinal, err := r.isFinalTask(txn, task)
 		if err != nil {
 			return fmt.Errorf("is final task: %w", err)
 		}
 
 		if !isFinal {
-			if err := r.enqueueChildTask(txn, task); err != nil {
-				return fmt.Errorf("enqueue child task: %w", err)
+			if err := r.enqueueChildTasks(txn, task); err != nil {
+				return fmt.Errorf("enqueue child tasks: %w", err)
 			}
 		} else {
 			if err := r.completeExpression(txn, task.ExpressionID, task); err != nil {
@@ -270,6 +391,69 @@ func (r *Repository) FinishTask(_ context.Context, cmd models.FinishTaskCmd) err
 	})
 }
 
+// ReclaimExpiredTasks returns in-progress tasks whose leases expired, e.g. because the agent crashed,
+// to the pending queue. A task that has already had maxAttempts expired leases fails its expression instead.
+func (r *Repository) ReclaimExpiredTasks(_ context.Context, maxAttempts int) (models.ReclaimedTasks, error) {
+	var reclaimed models.ReclaimedTasks
+
+	err := r.db.Update(func(txn *badger.Txn) error {
+		var taskIDs []string
+		it := txn.NewIterator(badger.IteratorOptions{PrefetchValues: false})
+		prefix := taskQueueInProgressPrefix()
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			taskIDs = append(taskIDs, taskIDFromInProgressQueueKey(it.Item().Key()))
+		}
+		it.Close()
+
+		timeNow := time.Now().UTC()
+		for _, taskID := range taskIDs {
+			var task models.Task
+			if err := scanVal(txn, taskKey(taskID), &task); err != nil {
+				return fmt.Errorf("get task: %w", err)
+			}
+
+			// The task may have been failed along with its expression by the previous task
+			if task.Status != models.TaskStatusInProgress || task.ExpireAt.After(timeNow) {
+				continue
+			}
+
+			task.Attempts++
+			if task.Attempts >= maxAttempts {
+				if err := setVal(txn, taskKey(task.ID), task); err != nil {
+					return fmt.Errorf("update task: %w", err)
+				}
+				if err := r.failExpression(txn, task.ExpressionID); err != nil {
+					return fmt.Errorf("fail expr: %w", err)
+				}
+				reclaimed.Failed++
+				continue
+			}
+
+			if err := txn.Delete(taskQueueInProgressKey(task.ID)); err != nil {
+				return fmt.Errorf("delete task from in-progress queue: %w", err)
+			}
+
+			task.Status = models.TaskStatusPending
+			task.UpdatedAt = timeNow
+			task.ExpireAt = time.Time{}
+			if err := setVal(txn, taskKey(task.ID), task); err != nil {
+				return fmt.Errorf("to pending task: %w", err)
+			}
+			if err := setOnlyKey(txn, taskQueuePendingKey(task.ID)); err != nil {
+				return fmt.Errorf("enqueue task: %w", err)
+			}
+			reclaimed.Requeued++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return models.ReclaimedTasks{}, err
+	}
+	return reclaimed, nil
+}
+
 // ListExpressionTasks retrieves all tasks associated with a specific expression.
 // Returns models.ErrExpressionNotFound if the expression doesn't exist.
 func (r *Repository) ListExpressionTasks(_ context.Context, id string) ([]models.Task, error) {
@@ -308,6 +492,173 @@ func (r *Repository) ListExpressionTasks(_ context.Context, id string) ([]models
 	return tasks, nil
 }
 
+// CreateFunction stores a new version of the function define returns for the current functions,
+// which replaces the current one with the same name, if any. Errors of define are returned as is.
+// Versions are numbered from 1 and keep counting after the function is deleted.
+func (r *Repository) CreateFunction(
+	_ context.Context,
+	define func(funcs []models.Function) (models.CreateFunctionCmd, error),
+) (models.Function, error) {
+	var f models.Function
+
+	// define is called again if the functions were changed concurrently
+	err := r.updateRetryingConflicts(func(txn *badger.Txn) error {
+		funcs, err := r.listFunctions(txn)
+		if err != nil {
+			return fmt.Errorf("list funcs: %w", err)
+		}
+		cmd, err := define(funcs)
+		if err != nil {
+			return err
+		}
+
+		f = models.Function{
+			Name:      cmd.Name,
+			Params:    cmd.Params,
+			Body:      cmd.Body,
+			CreatedAt: time.Now().UTC(),
+		}
+
+		// Find the latest version, the version keys aren't sorted numerically
+		it := txn.NewIterator(badger.IteratorOptions{PrefetchValues: false})
+		defer it.Close()
+
+		prefix := funcVersionPrefix(f.Name)
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			f.Version = max(f.Version, funcVersionFromVersionKey(it.Item().Key(), f.Name))
+		}
+		f.Version++
+
+		if err := setVal(txn, funcVersionKey(f.Name, f.Version), f); err != nil {
+			return fmt.Errorf("store func version: %w", err)
+		}
+		if err := setVal(txn, funcListKey(f.Name), f.Version); err != nil {
+			return fmt.Errorf("add to func list: %w", err)
+		}
+		if err := r.bumpFuncRevision(txn); err != nil {
+			return fmt.Errorf("bump func revision: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return models.Function{}, err
+	}
+	return f, nil
+}
+
+// ListFunctions retrieves the current versions of all functions.
+func (r *Repository) ListFunctions(_ context.Context) ([]models.Function, error) {
+	var funcs []models.Function
+
+	err := r.db.View(func(txn *badger.Txn) error {
+		var err error
+		funcs, err = r.listFunctions(txn)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return funcs, nil
+}
+
+// DeleteFunction removes the function from the list of current functions if check succeeds for the current functions.
+// Errors of check are returned as is. Its versions are kept.
+// Returns models.ErrFunctionNotFound if the function doesn't exist.
+func (r *Repository) DeleteFunction(_ context.Context, name string, check func(funcs []models.Function) error) error {
+	// check is called again if the functions were changed concurrently
+	return r.updateRetryingConflicts(func(txn *badger.Txn) error {
+		if _, err := txn.Get(funcListKey(name)); err != nil {
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return models.ErrFunctionNotFound
+			}
+			return fmt.Errorf("get func: %w", err)
+		}
+
+		funcs, err := r.listFunctions(txn)
+		if err != nil {
+			return fmt.Errorf("list funcs: %w", err)
+		}
+		if err := check(funcs); err != nil {
+			return err
+		}
+
+		if err := txn.Delete(funcListKey(name)); err != nil {
+			return fmt.Errorf("delete from func list: %w", err)
+		}
+		if err := r.bumpFuncRevision(txn); err != nil {
+			return fmt.Errorf("bump func revision: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) listFunctions(txn *badger.Txn) ([]models.Function, error) {
+	var funcs []models.Function
+
+	it := txn.NewIterator(badger.DefaultIteratorOptions)
+	defer it.Close()
+
+	prefix := funcListPrefix()
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		name := funcNameFromListKey(it.Item().Key())
+
+		var version int
+		if err := scanVal(txn, funcListKey(name), &version); err != nil {
+			return nil, fmt.Errorf("get func version: %w", err)
+		}
+
+		var f models.Function
+		if err := scanVal(txn, funcVersionKey(name, version), &f); err != nil {
+			return nil, fmt.Errorf("get func: %w", err)
+		}
+		funcs = append(funcs, f)
+	}
+	return funcs, nil
+}
+
+// bumpFuncRevision reads and increments the revision of the functions, so transactions changing functions conflict
+// with each other even if they read different functions, e.g. one defining a function that calls another
+// and one deleting the called function.
+func (r *Repository) bumpFuncRevision(txn *badger.Txn) error {
+	var revision int
+	if err := scanVal(txn, funcRevisionKey(), &revision); err != nil && !errors.Is(err, badger.ErrKeyNotFound) {
+		return fmt.Errorf("get func revision: %w", err)
+	}
+	return setVal(txn, funcRevisionKey(), revision+1)
+}
+
+// checkLease checks that the task is in progress under the lease with the token.
+func checkLease(task models.Task, leaseToken string) error {
+	if task.Status != models.TaskStatusInProgress {
+		return models.ErrTaskNotInProgress
+	}
+	if task.LeaseToken != leaseToken {
+		return models.ErrLeaseTokenMismatch
+	}
+	return nil
+}
+
+// isSameResult reports whether the task has already been finished with the result under the lease of cmd.
+func isSameResult(task models.Task, cmd models.FinishTaskCmd) bool {
+	return task.LeaseToken == cmd.LeaseToken && task.Status == cmd.Status &&
+		task.Result == cmd.Result && task.ExactResult == cmd.ExactResult
+}
+
+// updateRetryingConflicts runs fn in a read-write transaction like badger.DB.Update,
+// retrying it if the transaction conflicts with a concurrent one, e.g. a duplicate submission of a task result.
+// The retry sees the changes of the concurrent transaction.
+func (r *Repository) updateRetryingConflicts(fn func(txn *badger.Txn) error) error {
+	var err error
+	for range maxTxnConflictRetries + 1 {
+		if err = r.db.Update(fn); !errors.Is(err, badger.ErrConflict) {
+			return err
+		}
+	}
+	return err
+}
+
 func (r *Repository) isFinalTask(txn *badger.Txn, task models.Task) (bool, error) {
 	it := txn.NewIterator(badger.DefaultIteratorOptions)
 	defer it.Close()
@@ -318,28 +669,57 @@ func (r *Repository) isFinalTask(txn *badger.Txn, task models.Task) (bool, error
 	return task.ID == finalTaskID, nil
 }
 
-func (r *Repository) enqueueChildTask(txn *badger.Txn, completedTask models.Task) error {
-	it := txn.NewIterator(badger.DefaultIteratorOptions)
+// enqueueChildTasks passes the result of the completed task to all tasks depending on it
+// and enqueues the ones whose parents are all completed. Tasks of the untaken branch of the condition are skipped.
+func (r *Repository) enqueueChildTasks(txn *badger.Txn, completedTask models.Task) error {
+	for _, childTaskID := range r.childTaskIDs(txn, completedTask.ID) {
+		if err := r.enqueueChildTask(txn, completedTask, childTaskID); err != nil {
+			return fmt.Errorf("enqueue child task: %w", err)
+		}
+	}
+	return nil
+}
+
+// childTaskIDs returns IDs of the tasks depending on the task.
+func (r *Repository) childTaskIDs(txn *badger.Txn, taskID string) []string {
+	it := txn.NewIterator(badger.IteratorOptions{PrefetchValues: false})
 	defer it.Close()
 
-	// Find child task that depends on the completed task
-	it.Seek(taskChildPrefix(completedTask.ID))
-	if !it.ValidForPrefix(taskChildPrefix(completedTask.ID)) {
-		return nil // should not happen
+	var ids []string
+	prefix := taskChildPrefix(taskID)
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		ids = append(ids, taskIDFromTaskChildKey(it.Item().Key(), taskID))
 	}
+	return ids
+}
 
-	childTaskID := taskIDFromTaskChildKey(it.Item().Key(), completedTask.ID)
-
+func (r *Repository) enqueueChildTask(txn *badger.Txn, completedTask models.Task, childTaskID string) error {
 	var childTask models.Task
 	if err := scanVal(txn, taskKey(childTaskID), &childTask); err != nil {
 		return fmt.Errorf("get task: %w", err)
 	}
 
+	if childTask.ConditionTaskID == completedTask.ID && completedTask.IsTrue() != childTask.ConditionValue {
+		if err := r.skipTask(txn, childTask); err != nil {
+			return fmt.Errorf("skip task: %w", err)
+		}
+		return nil
+	}
+
 	// Update child task with parent's result value
 	if childTask.ParentTask1ID == completedTask.ID {
-		childTask.Arg1 = completedTask.Result
-	} else { // childTask.ParentTask2ID == completedTask.ID
-		childTask.Arg2 = completedTask.Result
+		childTask.Arg1, childTask.ExactArg1 = completedTask.Result, completedTask.ExactResult
+	}
+	if childTask.ParentTask2ID == completedTask.ID {
+		childTask.Arg2, childTask.ExactArg2 = completedTask.Result, completedTask.ExactResult
+	}
+	for i, parentID := range childTask.ParentTaskIDs {
+		if parentID == completedTask.ID {
+			childTask.Args[i] = completedTask.Result
+			if i < len(childTask.ExactArgs) {
+				childTask.ExactArgs[i] = completedTask.ExactResult
+			}
+		}
 	}
 	childTask.UpdatedAt = time.Now().UTC()
 
@@ -347,31 +727,96 @@ func (r *Repository) enqueueChildTask(txn *badger.Txn, completedTask models.Task
 		return fmt.Errorf("update task: %w", err)
 	}
 
-	// Check if both parents are complete and the task is ready to be queued
-	var parent1 models.Task
-	if childTask.ParentTask1ID != "" {
-		if err := scanVal(txn, taskKey(childTask.ParentTask1ID), &parent1); err != nil {
-			return fmt.Errorf("get parent 1 of task: %w", err) // 👨‍👩‍👦 😅
+	return r.enqueueIfReady(txn, childTask)
+}
+
+// enqueueIfReady enqueues the task if all parents it needs are complete.
+func (r *Repository) enqueueIfReady(txn *badger.Txn, task models.Task) error {
+	// "if" doesn't need the parent of the untaken branch, which is skipped
+	var untakenParentID string
+	if task.Operation == models.TaskOperationIf && task.ParentTaskIDs[0] != "" {
+		var cond models.Task
+		if err := scanVal(txn, taskKey(task.ParentTaskIDs[0]), &cond); err != nil {
+			return fmt.Errorf("get condition of task: %w", err)
+		}
+		if cond.Status != models.TaskStatusCompleted {
+			return nil
+		}
+		untakenParentID = task.ParentTaskIDs[2]
+		if !cond.IsTrue() {
+			untakenParentID = task.ParentTaskIDs[1]
 		}
 	}
-	var parent2 models.Task
-	if childTask.ParentTask2ID != "" {
-		if err := scanVal(txn, taskKey(childTask.ParentTask2ID), &parent2); err != nil {
-			return fmt.Errorf("get parent 2 of task: %w", err) // 👨‍👩‍👦 😅
+
+	for _, parentID := range task.ParentIDs() {
+		if parentID == untakenParentID {
+			continue
+		}
+		var parent models.Task
+		if err := scanVal(txn, taskKey(parentID), &parent); err != nil {
+			return fmt.Errorf("get parent of task: %w", err) // 👨‍👩‍👦 😅
 		}
+		if parent.Status != models.TaskStatusCompleted {
+			return nil
+		}
+		if parentID == task.ConditionTaskID && parent.IsTrue() != task.ConditionValue {
+			return nil // the task is in the untaken branch and is going to be skipped
+		}
+	}
+
+	if err := setOnlyKey(txn, taskQueuePendingKey(task.ID)); err != nil {
+		return fmt.Errorf("enqueue task: %w", err)
+	}
+	return nil
+}
+
+// skipTask marks the task of the untaken branch of a condition as skipped, along with the tasks depending on it.
+// "if" taking the result of the task as a branch doesn't depend on it, but may become ready instead.
+func (r *Repository) skipTask(txn *badger.Txn, task models.Task) error {
+	if task.Status == models.TaskStatusSkipped {
+		return nil // already skipped through another parent
 	}
 
-	if (childTask.ParentTask1ID == "" || parent1.Status == models.TaskStatusCompleted) &&
-		(childTask.ParentTask2ID == "" || parent2.Status == models.TaskStatusCompleted) {
-		if err := setOnlyKey(txn, taskQueuePendingKey(childTask.ID)); err != nil {
-			return fmt.Errorf("enqueue task: %w", err)
+	task.Status = models.TaskStatusSkipped
+	task.UpdatedAt = time.Now().UTC()
+	if err := setVal(txn, taskKey(task.ID), task); err != nil {
+		return fmt.Errorf("update task: %w", err)
+	}
+
+	for _, childTaskID := range r.childTaskIDs(txn, task.ID) {
+		var childTask models.Task
+		if err := scanVal(txn, taskKey(childTaskID), &childTask); err != nil {
+			return fmt.Errorf("get task: %w", err)
+		}
+
+		isBranch := childTask.Operation == models.TaskOperationIf &&
+			childTask.ParentTaskIDs[0] != task.ID && childTask.ConditionTaskID != task.ID
+		if isBranch {
+			if err := r.enqueueIfReady(txn, childTask); err != nil {
+				return fmt.Errorf("enqueue task: %w", err)
+			}
+			continue
+		}
+		if err := r.skipTask(txn, childTask); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
 func (r *Repository) failExpression(txn *badger.Txn, exprID string) error {
-	// Mark all unfinished tasks as failed
+	return r.stopExpression(txn, exprID, models.ExpressionStatusFailed, models.TaskStatusFailed)
+}
+
+// stopExpression removes the unfinished tasks of the expression from the queues
+// and sets their status and the status of the expression.
+func (r *Repository) stopExpression(
+	txn *badger.Txn,
+	exprID string,
+	exprStatus models.ExpressionStatus,
+	taskStatus models.TaskStatus,
+) error {
+	// Mark all unfinished tasks
 	it := txn.NewIterator(badger.DefaultIteratorOptions)
 	defer it.Close()
 
@@ -384,26 +829,28 @@ func (r *Repository) failExpression(txn *badger.Txn, exprID string) error {
 			return fmt.Errorf("get task: %w", err)
 		}
 
-		if task.Status == models.TaskStatusCompleted || task.Status == models.TaskStatusFailed {
+		if task.Status == models.TaskStatusCompleted || task.Status == models.TaskStatusFailed ||
+			task.Status == models.TaskStatusSkipped || task.Status == models.TaskStatusCancelled {
 			continue
 		}
 
 		_ = txn.Delete(taskQueuePendingKey(task.ID))
+		_ = txn.Delete(taskQueueInProgressKey(task.ID))
 
-		task.Status = models.TaskStatusFailed
+		task.Status = taskStatus
 		task.UpdatedAt = time.Now().UTC()
 		if err := setVal(txn, taskKey(task.ID), task); err != nil {
 			return fmt.Errorf("update task: %w", err)
 		}
 	}
 
-	// Mark the expression as failed
+	// Mark the expression
 	var expr models.Expression
 	if err := scanVal(txn, exprKey(exprID), &expr); err != nil {
 		return fmt.Errorf("get expr: %w", err)
 	}
 
-	expr.Status = models.ExpressionStatusFailed
+	expr.Status = exprStatus
 	expr.UpdatedAt = time.Now().UTC()
 	if err := setVal(txn, exprKey(exprID), expr); err != nil {
 		return fmt.Errorf("update expr: %w", err)
@@ -420,6 +867,7 @@ func (r *Repository) completeExpression(txn *badger.Txn, exprID string, finalTas
 
 	expr.Status = models.ExpressionStatusCompleted
 	expr.Result = finalTask.Result
+	expr.ExactResult = finalTask.ExactResult
 	expr.UpdatedAt = time.Now().UTC()
 	if err := setVal(txn, exprKey(expr.ID), expr); err != nil {
 		return fmt.Errorf("update expr: %w", err)
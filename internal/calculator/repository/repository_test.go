@@ -0,0 +1,573 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/repository/models"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepository(t *testing.T) *Repository {
+	t.Helper()
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	return New(db)
+}
+
+// createSumProduct creates the expression (1 + 2) * (3 + 4) and returns its ID.
+func createSumProduct(t *testing.T, r *Repository) string {
+	t.Helper()
+	id, err := r.CreateExpression(context.Background(), models.CreateExpressionCmd{
+		Expression: "(1 + 2) * (3 + 4)",
+		Arithmetic: models.ArithmeticFloat,
+	}, []models.CreateExpressionTaskCmd{
+		{ID: "sum1", Arg1: 1, Arg2: 2, Operation: models.TaskOperationAddition},
+		{ID: "sum2", Arg1: 3, Arg2: 4, Operation: models.TaskOperationAddition},
+		{ID: "product", ParentTask1ID: "sum1", ParentTask2ID: "sum2", Operation: models.TaskOperationMultiplication},
+	})
+	require.NoError(t, err)
+	return id
+}
+
+func TestRepository_FinishTask(t *testing.T) {
+	type args struct {
+		cmd func(claimed models.Task) models.FinishTaskCmd
+	}
+	tests := []struct {
+		name    string
+		finish  func(r *Repository, claimed models.Task) // finishes the task before the tested call
+		args    args
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "result submitted",
+			args: args{cmd: func(claimed models.Task) models.FinishTaskCmd {
+				return models.FinishTaskCmd{ID: claimed.ID, LeaseToken: claimed.LeaseToken, Status: models.TaskStatusCompleted, Result: 3}
+			}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "same result submitted again",
+			finish: func(r *Repository, claimed models.Task) {
+				_ = r.FinishTask(context.Background(), models.FinishTaskCmd{
+					ID: claimed.ID, LeaseToken: claimed.LeaseToken, Status: models.TaskStatusCompleted, Result: 3,
+				})
+			},
+			args: args{cmd: func(claimed models.Task) models.FinishTaskCmd {
+				return models.FinishTaskCmd{ID: claimed.ID, LeaseToken: claimed.LeaseToken, Status: models.TaskStatusCompleted, Result: 3}
+			}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "another result submitted",
+			finish: func(r *Repository, claimed models.Task) {
+				_ = r.FinishTask(context.Background(), models.FinishTaskCmd{
+					ID: claimed.ID, LeaseToken: claimed.LeaseToken, Status: models.TaskStatusCompleted, Result: 3,
+				})
+			},
+			args: args{cmd: func(claimed models.Task) models.FinishTaskCmd {
+				return models.FinishTaskCmd{ID: claimed.ID, LeaseToken: claimed.LeaseToken, Status: models.TaskStatusCompleted, Result: 4}
+			}},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, models.ErrTaskNotInProgress, msgAndArgs...)
+			},
+		},
+		{
+			name: "stale lease token",
+			args: args{cmd: func(claimed models.Task) models.FinishTaskCmd {
+				return models.FinishTaskCmd{ID: claimed.ID, LeaseToken: "stale", Status: models.TaskStatusCompleted, Result: 3}
+			}},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, models.ErrLeaseTokenMismatch, msgAndArgs...)
+			},
+		},
+		{
+			name: "task not found",
+			args: args{cmd: func(models.Task) models.FinishTaskCmd {
+				return models.FinishTaskCmd{ID: "nonexistent", Status: models.TaskStatusCompleted, Result: 3}
+			}},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, models.ErrTaskNotFound, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := newTestRepository(t)
+			createSumProduct(t, r)
+
+			claimed, err := r.GetPendingTask(ctx, time.Minute)
+			require.NoError(t, err)
+			if tt.finish != nil {
+				tt.finish(r, claimed)
+			}
+
+			cmd := tt.args.cmd(claimed)
+			tt.wantErr(t, r.FinishTask(ctx, cmd), fmt.Sprintf("FinishTask(%v, %v)", ctx, cmd))
+		})
+	}
+}
+
+func TestRepository_FinishTask_concurrentDuplicates(t *testing.T) {
+	const duplicates = 50
+
+	ctx := context.Background()
+	r := newTestRepository(t)
+	exprID := createSumProduct(t, r)
+
+	// submitConcurrently submits the result of the claimed task as many times at once as a retrying agent could
+	submitConcurrently := func(tasks ...models.Task) {
+		var wg sync.WaitGroup
+		for _, task := range tasks {
+			cmd := models.FinishTaskCmd{
+				ID:         task.ID,
+				LeaseToken: task.LeaseToken,
+				Status:     models.TaskStatusCompleted,
+				Result:     task.Arg1 + task.Arg2,
+			}
+			if task.Operation == models.TaskOperationMultiplication {
+				cmd.Result = task.Arg1 * task.Arg2
+			}
+			for range duplicates {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					assert.NoError(t, r.FinishTask(ctx, cmd))
+				}()
+			}
+		}
+		wg.Wait()
+	}
+
+	sum1, err := r.GetPendingTask(ctx, time.Minute)
+	require.NoError(t, err)
+	sum2, err := r.GetPendingTask(ctx, time.Minute)
+	require.NoError(t, err)
+	submitConcurrently(sum1, sum2)
+
+	product, err := r.GetPendingTask(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "product", product.ID)
+	assert.Equal(t, 3.0, product.Arg1)
+	assert.Equal(t, 7.0, product.Arg2)
+	_, err = r.GetPendingTask(ctx, time.Minute)
+	assert.ErrorIs(t, err, models.ErrNoPendingTasks, "the child task must be enqueued exactly once")
+
+	submitConcurrently(product)
+
+	expr, err := r.GetExpression(ctx, exprID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExpressionStatusCompleted, expr.Status)
+	assert.Equal(t, 21.0, expr.Result)
+
+	tasks, err := r.ListExpressionTasks(ctx, exprID)
+	require.NoError(t, err)
+	for _, task := range tasks {
+		assert.Equal(t, models.TaskStatusCompleted, task.Status, task.ID)
+	}
+	_, err = r.GetPendingTask(ctx, time.Minute)
+	assert.ErrorIs(t, err, models.ErrNoPendingTasks)
+}
+
+func TestRepository_CancelExpression(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+	exprID := createSumProduct(t, r)
+
+	claimed, err := r.GetPendingTask(ctx, time.Minute)
+	require.NoError(t, err)
+
+	expr, err := r.CancelExpression(ctx, exprID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExpressionStatusCancelled, expr.Status)
+
+	_, err = r.GetPendingTask(ctx, time.Minute)
+	assert.ErrorIs(t, err, models.ErrNoPendingTasks, "pending tasks must be removed from the queue")
+	_, err = r.ExtendTaskLease(ctx, claimed.ID, claimed.LeaseToken, time.Minute)
+	assert.ErrorIs(t, err, models.ErrTaskNotInProgress)
+
+	// The result of the task being processed is discarded
+	assert.NoError(t, r.FinishTask(ctx, models.FinishTaskCmd{
+		ID:         claimed.ID,
+		LeaseToken: claimed.LeaseToken,
+		Status:     models.TaskStatusCompleted,
+		Result:     3,
+	}))
+	_, err = r.GetPendingTask(ctx, time.Minute)
+	assert.ErrorIs(t, err, models.ErrNoPendingTasks, "children of discarded results must not be enqueued")
+
+	tasks, err := r.ListExpressionTasks(ctx, exprID)
+	require.NoError(t, err)
+	for _, task := range tasks {
+		assert.Equal(t, models.TaskStatusCancelled, task.Status, task.ID)
+	}
+
+	expr, err = r.CancelExpression(ctx, exprID)
+	require.NoError(t, err, "cancelling again is a no-op")
+	assert.Equal(t, models.ExpressionStatusCancelled, expr.Status)
+
+	reclaimed, err := r.ReclaimExpiredTasks(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReclaimedTasks{}, reclaimed)
+}
+
+func TestRepository_CancelExpression_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, r *Repository) string // returns the ID of the expression to cancel
+		wantErr error
+	}{
+		{
+			name: "completed expression",
+			setup: func(t *testing.T, r *Repository) string {
+				id, _ := r.CreateExpression(context.Background(), models.CreateExpressionCmd{Expression: "42", Result: 42}, nil)
+				return id
+			},
+			wantErr: models.ErrExpressionFinished,
+		},
+		{
+			name: "failed expression",
+			setup: func(t *testing.T, r *Repository) string {
+				ctx := context.Background()
+				id := createSumProduct(t, r)
+				task, _ := r.GetPendingTask(ctx, time.Minute)
+				_ = r.FinishTask(ctx, models.FinishTaskCmd{ID: task.ID, LeaseToken: task.LeaseToken, Status: models.TaskStatusFailed})
+				return id
+			},
+			wantErr: models.ErrExpressionFinished,
+		},
+		{
+			name: "expression not found",
+			setup: func(*testing.T, *Repository) string {
+				return "nonexistent"
+			},
+			wantErr: models.ErrExpressionNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepository(t)
+			id := tt.setup(t, r)
+
+			_, err := r.CancelExpression(context.Background(), id)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+// calculate claims and finishes pending tasks like an agent would until none are left,
+// and returns the number of times each task was claimed.
+func calculate(t *testing.T, r *Repository) map[string]int {
+	t.Helper()
+	ctx := context.Background()
+
+	claimed := map[string]int{}
+	for {
+		task, err := r.GetPendingTask(ctx, time.Minute)
+		if errors.Is(err, models.ErrNoPendingTasks) {
+			return claimed
+		}
+		require.NoError(t, err)
+		claimed[task.ID]++
+
+		var result float64
+		switch task.Operation {
+		case models.TaskOperationAddition:
+			result = task.Arg1 + task.Arg2
+		case models.TaskOperationSubtraction:
+			result = task.Arg1 - task.Arg2
+		case models.TaskOperationMultiplication:
+			result = task.Arg1 * task.Arg2
+		case models.TaskOperationGreater:
+			if task.Arg1 > task.Arg2 {
+				result = 1
+			}
+		case models.TaskOperationIf:
+			result = task.Args[2]
+			if task.Args[0] != 0 {
+				result = task.Args[1]
+			}
+		default:
+			require.FailNow(t, "unexpected operation", task.Operation)
+		}
+		require.NoError(t, r.FinishTask(ctx, models.FinishTaskCmd{
+			ID:         task.ID,
+			LeaseToken: task.LeaseToken,
+			Status:     models.TaskStatusCompleted,
+			Result:     result,
+		}))
+	}
+}
+
+func TestRepository_FinishTask_conditions(t *testing.T) {
+	// if(x > 1, x * 2, x + 1)
+	ifTasks := func(x float64) []models.CreateExpressionTaskCmd {
+		return []models.CreateExpressionTaskCmd{
+			{ID: "cond", Arg1: x, Arg2: 1, Operation: models.TaskOperationGreater},
+			{ID: "then", Arg1: x, Arg2: 2, Operation: models.TaskOperationMultiplication, ConditionTaskID: "cond", ConditionValue: true},
+			{ID: "else", Arg1: x, Arg2: 1, Operation: models.TaskOperationAddition, ConditionTaskID: "cond"},
+			{ID: "if", ParentTaskIDs: []string{"cond", "then", "else"}, Args: make([]float64, 3), Operation: models.TaskOperationIf},
+		}
+	}
+	// if(x > 1, if(x > 2, x * 2, x + 1), x - 1)
+	nestedIfTasks := func(x float64) []models.CreateExpressionTaskCmd {
+		return []models.CreateExpressionTaskCmd{
+			{ID: "outer_cond", Arg1: x, Arg2: 1, Operation: models.TaskOperationGreater},
+			{ID: "inner_cond", Arg1: x, Arg2: 2, Operation: models.TaskOperationGreater, ConditionTaskID: "outer_cond", ConditionValue: true},
+			{ID: "inner_then", Arg1: x, Arg2: 2, Operation: models.TaskOperationMultiplication, ConditionTaskID: "inner_cond", ConditionValue: true},
+			{ID: "inner_else", Arg1: x, Arg2: 1, Operation: models.TaskOperationAddition, ConditionTaskID: "inner_cond"},
+			{
+				ID:              "inner_if",
+				ParentTaskIDs:   []string{"inner_cond", "inner_then", "inner_else"},
+				Args:            make([]float64, 3),
+				Operation:       models.TaskOperationIf,
+				ConditionTaskID: "outer_cond",
+				ConditionValue:  true,
+			},
+			{ID: "outer_else", Arg1: x, Arg2: 1, Operation: models.TaskOperationSubtraction, ConditionTaskID: "outer_cond"},
+			{ID: "outer_if", ParentTaskIDs: []string{"outer_cond", "inner_if", "outer_else"}, Args: make([]float64, 3), Operation: models.TaskOperationIf},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		tasks       []models.CreateExpressionTaskCmd
+		want        float64
+		wantSkipped []string
+	}{
+		{
+			name:        "condition is true",
+			tasks:       ifTasks(2),
+			want:        4,
+			wantSkipped: []string{"else"},
+		},
+		{
+			name:        "condition is false",
+			tasks:       ifTasks(0),
+			want:        1,
+			wantSkipped: []string{"then"},
+		},
+		{
+			name:        "nested conditions are true",
+			tasks:       nestedIfTasks(3),
+			want:        6,
+			wantSkipped: []string{"inner_else", "outer_else"},
+		},
+		{
+			name:        "nested condition is false",
+			tasks:       nestedIfTasks(2),
+			want:        3,
+			wantSkipped: []string{"inner_then", "outer_else"},
+		},
+		{
+			name:        "outer condition is false",
+			tasks:       nestedIfTasks(0),
+			want:        -1,
+			wantSkipped: []string{"inner_cond", "inner_then", "inner_else", "inner_if"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := newTestRepository(t)
+			exprID, err := r.CreateExpression(ctx, models.CreateExpressionCmd{Arithmetic: models.ArithmeticFloat}, tt.tasks)
+			require.NoError(t, err)
+
+			claimed := calculate(t, r)
+
+			expr, err := r.GetExpression(ctx, exprID)
+			require.NoError(t, err)
+			assert.Equal(t, models.ExpressionStatusCompleted, expr.Status)
+			assert.Equal(t, tt.want, expr.Result)
+
+			tasks, err := r.ListExpressionTasks(ctx, exprID)
+			require.NoError(t, err)
+			require.Len(t, tasks, len(tt.tasks))
+			for _, task := range tasks {
+				if slices.Contains(tt.wantSkipped, task.ID) {
+					assert.Equal(t, models.TaskStatusSkipped, task.Status, task.ID)
+					assert.Zero(t, claimed[task.ID], "skipped task %s must never be queued", task.ID)
+				} else {
+					assert.Equal(t, models.TaskStatusCompleted, task.Status, task.ID)
+					assert.Equal(t, 1, claimed[task.ID], "task %s must be queued exactly once", task.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestRepository_FinishTask_sharedTasks(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	// (1+2)*(1+2) - ((1+2)+1), the sum is shared by two children and is both operands of one of them
+	exprID, err := r.CreateExpression(ctx, models.CreateExpressionCmd{Arithmetic: models.ArithmeticFloat}, []models.CreateExpressionTaskCmd{
+		{ID: "sum", Arg1: 1, Arg2: 2, Operation: models.TaskOperationAddition},
+		{ID: "square", ParentTask1ID: "sum", ParentTask2ID: "sum", Operation: models.TaskOperationMultiplication},
+		{ID: "increment", ParentTask1ID: "sum", Arg2: 1, Operation: models.TaskOperationAddition},
+		{ID: "difference", ParentTask1ID: "square", ParentTask2ID: "increment", Operation: models.TaskOperationSubtraction},
+	})
+	require.NoError(t, err)
+
+	claimed := calculate(t, r)
+	assert.Equal(t, map[string]int{"sum": 1, "square": 1, "increment": 1, "difference": 1}, claimed,
+		"every task must be queued exactly once")
+
+	expr, err := r.GetExpression(ctx, exprID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExpressionStatusCompleted, expr.Status)
+	assert.Equal(t, 5.0, expr.Result)
+
+	tasks, err := r.ListExpressionTasks(ctx, exprID)
+	require.NoError(t, err)
+	args := map[string][2]float64{}
+	for _, task := range tasks {
+		assert.Equal(t, models.TaskStatusCompleted, task.Status, task.ID)
+		args[task.ID] = [2]float64{task.Arg1, task.Arg2}
+	}
+	assert.Equal(t, [2]float64{3, 3}, args["square"])
+	assert.Equal(t, [2]float64{3, 1}, args["increment"])
+	assert.Equal(t, [2]float64{9, 4}, args["difference"])
+}
+
+func TestRepository_ReclaimExpiredTasks(t *testing.T) {
+	const expiredLease = -time.Second
+
+	ctx := context.Background()
+	r := newTestRepository(t)
+	exprID := createSumProduct(t, r)
+
+	expired, err := r.GetPendingTask(ctx, expiredLease)
+	require.NoError(t, err)
+	unexpired, err := r.GetPendingTask(ctx, time.Minute)
+	require.NoError(t, err)
+
+	reclaimed, err := r.ReclaimExpiredTasks(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReclaimedTasks{Requeued: 1}, reclaimed)
+
+	err = r.db.View(func(txn *badger.Txn) error {
+		_, err := txn.Get(taskQueuePendingKey(expired.ID))
+		return err
+	})
+	assert.NoError(t, err, "the expired task must be returned to the pending queue")
+
+	requeued, err := r.GetPendingTask(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, expired.ID, requeued.ID)
+	assert.Equal(t, 1, requeued.Attempts)
+	assert.NotEqual(t, expired.LeaseToken, requeued.LeaseToken)
+	assert.ErrorIs(t, r.FinishTask(ctx, models.FinishTaskCmd{
+		ID: expired.ID, LeaseToken: expired.LeaseToken, Status: models.TaskStatusCompleted, Result: 3,
+	}), models.ErrLeaseTokenMismatch, "the result of the expired lease must be rejected")
+
+	// The unexpired task is left alone
+	tasks, err := r.ListExpressionTasks(ctx, exprID)
+	require.NoError(t, err)
+	for _, task := range tasks {
+		if task.ID == unexpired.ID {
+			assert.Equal(t, models.TaskStatusInProgress, task.Status)
+			assert.Zero(t, task.Attempts)
+		}
+	}
+	assert.NoError(t, r.FinishTask(ctx, models.FinishTaskCmd{
+		ID: unexpired.ID, LeaseToken: unexpired.LeaseToken, Status: models.TaskStatusCompleted, Result: 7,
+	}))
+
+	expr, err := r.GetExpression(ctx, exprID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExpressionStatusInProgress, expr.Status)
+}
+
+func TestRepository_ReclaimExpiredTasks_maxAttempts(t *testing.T) {
+	const (
+		expiredLease = -time.Second
+		maxAttempts  = 3
+	)
+
+	ctx := context.Background()
+	r := newTestRepository(t)
+	exprID := createSumProduct(t, r)
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		task, err := r.GetPendingTask(ctx, expiredLease)
+		require.NoError(t, err)
+		assert.Equal(t, attempt-1, task.Attempts)
+
+		reclaimed, err := r.ReclaimExpiredTasks(ctx, maxAttempts)
+		require.NoError(t, err)
+		if attempt < maxAttempts {
+			assert.Equal(t, models.ReclaimedTasks{Requeued: 1}, reclaimed, "attempt %d", attempt)
+		} else {
+			assert.Equal(t, models.ReclaimedTasks{Failed: 1}, reclaimed, "attempt %d", attempt)
+		}
+	}
+
+	expr, err := r.GetExpression(ctx, exprID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExpressionStatusFailed, expr.Status)
+
+	tasks, err := r.ListExpressionTasks(ctx, exprID)
+	require.NoError(t, err)
+	for _, task := range tasks {
+		assert.Equal(t, models.TaskStatusFailed, task.Status, task.ID)
+	}
+	_, err = r.GetPendingTask(ctx, time.Minute)
+	assert.ErrorIs(t, err, models.ErrNoPendingTasks)
+
+	reclaimed, err := r.ReclaimExpiredTasks(ctx, maxAttempts)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReclaimedTasks{}, reclaimed, "tasks of the failed expression must not be reclaimed")
+}
+
+func TestRepository_CreateFunction_concurrentChanges(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+	defineCmd := func(cmd models.CreateFunctionCmd) func([]models.Function) (models.CreateFunctionCmd, error) {
+		return func([]models.Function) (models.CreateFunctionCmd, error) { return cmd, nil }
+	}
+	_, err := r.CreateFunction(ctx, defineCmd(models.CreateFunctionCmd{Name: "g", Params: []string{"x"}, Body: "x + 1"}))
+	require.NoError(t, err)
+
+	// g is deleted while f calling it is being defined, so f must be defined again without g
+	var seen [][]string
+	f, err := r.CreateFunction(ctx, func(funcs []models.Function) (models.CreateFunctionCmd, error) {
+		var names []string
+		for _, f := range funcs {
+			names = append(names, f.Name)
+		}
+		seen = append(seen, names)
+		if len(seen) == 1 {
+			require.NoError(t, r.DeleteFunction(ctx, "g", func([]models.Function) error { return nil }))
+		}
+		return models.CreateFunctionCmd{Name: "f", Params: []string{"y"}, Body: "y * 2"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"g"}, nil}, seen)
+	assert.Equal(t, 1, f.Version)
+
+	// Functions aren't changed if they are rejected
+	_, err = r.CreateFunction(ctx, func([]models.Function) (models.CreateFunctionCmd, error) {
+		return models.CreateFunctionCmd{}, assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.ErrorIs(t, r.DeleteFunction(ctx, "f", func([]models.Function) error { return assert.AnError }), assert.AnError)
+	assert.ErrorIs(t, r.DeleteFunction(ctx, "g", func([]models.Function) error { return nil }), models.ErrFunctionNotFound)
+
+	funcs, err := r.ListFunctions(ctx)
+	require.NoError(t, err)
+	require.Len(t, funcs, 1)
+	assert.Equal(t, "f", funcs[0].Name)
+}
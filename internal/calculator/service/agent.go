@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"math"
+	"time"
 
 	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/config"
 	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/repository/models"
@@ -15,11 +16,14 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type AgentRepository interface {
-	GetPendingTask(context.Context) (models.Task, error)
+	GetPendingTask(context.Context, time.Duration) (models.Task, error)
+	ExtendTaskLease(context.Context, string, string, time.Duration) (time.Time, error)
 	FinishTask(context.Context, models.FinishTaskCmd) error
 }
 
@@ -47,7 +51,7 @@ func (s *AgentService) RegisterGRPCGateway(ctx context.Context, mux *runtime.Ser
 }
 
 func (s *AgentService) GetTask(ctx context.Context, _ *emptypb.Empty) (*calculatorv1.GetTaskResponse, error) {
-	task, err := s.repo.GetPendingTask(ctx)
+	task, err := s.repo.GetPendingTask(ctx, s.lease())
 	if err != nil {
 		if errors.Is(err, models.ErrNoPendingTasks) {
 			return nil, status.Error(codes.NotFound, "no pending tasks")
@@ -56,23 +60,45 @@ func (s *AgentService) GetTask(ctx context.Context, _ *emptypb.Empty) (*calculat
 	}
 
 	return &calculatorv1.GetTaskResponse{
-		Task: mapTaskToAgentTaskResponse(task),
+		Task:          mapTaskToAgentTaskResponse(task),
+		LeaseDuration: durationpb.New(s.lease()),
+		LeaseToken:    task.LeaseToken,
 	}, nil
 }
 
+func (s *AgentService) ExtendTaskLease(
+	ctx context.Context,
+	req *calculatorv1.ExtendTaskLeaseRequest,
+) (*calculatorv1.ExtendTaskLeaseResponse, error) {
+	expireAt, err := s.repo.ExtendTaskLease(ctx, req.Id, req.LeaseToken, s.lease())
+	if err != nil {
+		if errors.Is(err, models.ErrTaskNotFound) {
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
+		if errors.Is(err, models.ErrTaskNotInProgress) || errors.Is(err, models.ErrLeaseTokenMismatch) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, InternalError(fmt.Errorf("extend task lease: %w", err))
+	}
+	return &calculatorv1.ExtendTaskLeaseResponse{ExpireAt: timestamppb.New(expireAt)}, nil
+}
+
 func (s *AgentService) SubmitTaskResult(ctx context.Context, req *calculatorv1.SubmitTaskResultRequest) (*emptypb.Empty, error) {
 	var finishTaskCmd models.FinishTaskCmd
-	if math.IsNaN(req.Result) {
+	if math.IsNaN(req.Result) || math.IsInf(req.Result, 0) { // infinities can't be stored nor calculated further
 		finishTaskCmd = models.FinishTaskCmd{
-			ID:     req.Id,
-			Status: models.TaskStatusFailed,
-			Result: 0,
+			ID:         req.Id,
+			LeaseToken: req.LeaseToken,
+			Status:     models.TaskStatusFailed,
+			Result:     0,
 		}
 	} else {
 		finishTaskCmd = models.FinishTaskCmd{
-			ID:     req.Id,
-			Status: models.TaskStatusCompleted,
-			Result: req.Result,
+			ID:          req.Id,
+			LeaseToken:  req.LeaseToken,
+			Status:      models.TaskStatusCompleted,
+			Result:      req.Result,
+			ExactResult: req.ExactResult,
 		}
 	}
 
@@ -80,7 +106,14 @@ func (s *AgentService) SubmitTaskResult(ctx context.Context, req *calculatorv1.S
 		if errors.Is(err, models.ErrTaskNotFound) {
 			return nil, status.Error(codes.NotFound, "task not found")
 		}
+		if errors.Is(err, models.ErrTaskNotInProgress) || errors.Is(err, models.ErrLeaseTokenMismatch) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, InternalError(fmt.Errorf("finish task: %w", err))
 	}
 	return &emptypb.Empty{}, nil
 }
+
+func (s *AgentService) lease() time.Duration {
+	return time.Duration(s.conf.TaskLeaseMs) * time.Millisecond
+}
@@ -14,8 +14,11 @@ import (
 	calculatorv1 "github.com/belo4ya/edu-dist-calculate-api/pkg/calculator/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestAgentService_GetTask(t *testing.T) {
@@ -28,7 +31,7 @@ func TestAgentService_GetTask(t *testing.T) {
 		{
 			name: "successfully retrieve pending task",
 			setupMocks: func(repo *mocks.MockAgentRepository) {
-				repo.EXPECT().GetPendingTask(mock.Anything).Return(models.Task{
+				repo.EXPECT().GetPendingTask(mock.Anything, 30*time.Second).Return(models.Task{
 					ID:            "task1",
 					ExpressionID:  "expr1",
 					ParentTask1ID: "parent1",
@@ -37,7 +40,8 @@ func TestAgentService_GetTask(t *testing.T) {
 					Arg2:          3,
 					Operation:     models.TaskOperationAddition,
 					OperationTime: time.Second,
-					Status:        models.TaskStatusPending,
+					Status:        models.TaskStatusInProgress,
+					LeaseToken:    "lease1",
 				}, nil)
 			},
 			want: &calculatorv1.GetTaskResponse{
@@ -48,13 +52,39 @@ func TestAgentService_GetTask(t *testing.T) {
 					Operation:     calculatorv1.TaskOperation_TASK_OPERATION_ADDITION,
 					OperationTime: durationpb.New(time.Second),
 				},
+				LeaseDuration: durationpb.New(30 * time.Second),
+				LeaseToken:    "lease1",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "successfully retrieve pending function task",
+			setupMocks: func(repo *mocks.MockAgentRepository) {
+				repo.EXPECT().GetPendingTask(mock.Anything, 30*time.Second).Return(models.Task{
+					ID:            "task2",
+					ExpressionID:  "expr1",
+					ParentTaskIDs: []string{"", "parent1"},
+					Args:          []float64{1, 5},
+					Operation:     models.TaskOperationMax,
+					OperationTime: time.Second,
+					Status:        models.TaskStatusPending,
+				}, nil)
+			},
+			want: &calculatorv1.GetTaskResponse{
+				Task: &calculatorv1.Task{
+					Id:            "task2",
+					Args:          []float64{1, 5},
+					Operation:     calculatorv1.TaskOperation_TASK_OPERATION_MAX,
+					OperationTime: durationpb.New(time.Second),
+				},
+				LeaseDuration: durationpb.New(30 * time.Second),
 			},
 			wantErr: assert.NoError,
 		},
 		{
 			name: "no pending tasks",
 			setupMocks: func(repo *mocks.MockAgentRepository) {
-				repo.EXPECT().GetPendingTask(mock.Anything).Return(models.Task{}, models.ErrNoPendingTasks)
+				repo.EXPECT().GetPendingTask(mock.Anything, 30*time.Second).Return(models.Task{}, models.ErrNoPendingTasks)
 			},
 			want:    nil,
 			wantErr: assert.Error,
@@ -62,7 +92,7 @@ func TestAgentService_GetTask(t *testing.T) {
 		{
 			name: "repository error",
 			setupMocks: func(repo *mocks.MockAgentRepository) {
-				repo.EXPECT().GetPendingTask(mock.Anything).Return(models.Task{}, assert.AnError)
+				repo.EXPECT().GetPendingTask(mock.Anything, 30*time.Second).Return(models.Task{}, assert.AnError)
 			},
 			want:    nil,
 			wantErr: assert.Error,
@@ -75,7 +105,7 @@ func TestAgentService_GetTask(t *testing.T) {
 			repo := mocks.NewMockAgentRepository(t)
 
 			tt.setupMocks(repo)
-			svc := NewAgentService(&config.Config{}, testutil.DiscardLogger(), repo)
+			svc := NewAgentService(&config.Config{TaskLeaseMs: 30000}, testutil.DiscardLogger(), repo)
 
 			got, err := svc.GetTask(ctx, &emptypb.Empty{})
 			if !tt.wantErr(t, err, fmt.Sprintf("GetTask(%v, %v)", ctx, &emptypb.Empty{})) {
@@ -86,7 +116,75 @@ func TestAgentService_GetTask(t *testing.T) {
 	}
 }
 
+func TestAgentService_ExtendTaskLease(t *testing.T) {
+	expireAt := time.Date(2025, 3, 1, 12, 0, 30, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		setupMocks func(repo *mocks.MockAgentRepository)
+		want       *calculatorv1.ExtendTaskLeaseResponse
+		wantCode   codes.Code
+	}{
+		{
+			name: "lease extended",
+			setupMocks: func(repo *mocks.MockAgentRepository) {
+				repo.EXPECT().ExtendTaskLease(mock.Anything, "task1", "lease1", 30*time.Second).Return(expireAt, nil)
+			},
+			want:     &calculatorv1.ExtendTaskLeaseResponse{ExpireAt: timestamppb.New(expireAt)},
+			wantCode: codes.OK,
+		},
+		{
+			name: "task not found",
+			setupMocks: func(repo *mocks.MockAgentRepository) {
+				repo.EXPECT().ExtendTaskLease(mock.Anything, "task1", "lease1", 30*time.Second).Return(time.Time{}, models.ErrTaskNotFound)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "lease already expired",
+			setupMocks: func(repo *mocks.MockAgentRepository) {
+				repo.EXPECT().ExtendTaskLease(mock.Anything, "task1", "lease1", 30*time.Second).Return(time.Time{}, models.ErrTaskNotInProgress)
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "task handed to another agent",
+			setupMocks: func(repo *mocks.MockAgentRepository) {
+				repo.EXPECT().ExtendTaskLease(mock.Anything, "task1", "lease1", 30*time.Second).Return(time.Time{}, models.ErrLeaseTokenMismatch)
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(repo *mocks.MockAgentRepository) {
+				repo.EXPECT().ExtendTaskLease(mock.Anything, "task1", "lease1", 30*time.Second).Return(time.Time{}, assert.AnError)
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := mocks.NewMockAgentRepository(t)
+
+			tt.setupMocks(repo)
+			svc := NewAgentService(&config.Config{TaskLeaseMs: 30000}, testutil.DiscardLogger(), repo)
+
+			got, err := svc.ExtendTaskLease(ctx, &calculatorv1.ExtendTaskLeaseRequest{Id: "task1", LeaseToken: "lease1"})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestAgentService_SubmitTaskResult(t *testing.T) {
+	errorHasCode := func(code codes.Code) assert.ErrorAssertionFunc {
+		return func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+			return assert.Equal(t, code, status.Code(err), msgAndArgs...)
+		}
+	}
+
 	tests := []struct {
 		name       string
 		setupMocks func(repo *mocks.MockAgentRepository)
@@ -97,14 +195,33 @@ func TestAgentService_SubmitTaskResult(t *testing.T) {
 			name: "successfully submit completed task result",
 			setupMocks: func(repo *mocks.MockAgentRepository) {
 				repo.EXPECT().FinishTask(mock.Anything, models.FinishTaskCmd{
-					ID:     "task1",
-					Status: models.TaskStatusCompleted,
-					Result: 42.0,
+					ID:         "task1",
+					LeaseToken: "lease1",
+					Status:     models.TaskStatusCompleted,
+					Result:     42.0,
 				}).Return(nil)
 			},
 			req: &calculatorv1.SubmitTaskResultRequest{
-				Id:     "task1",
-				Result: 42.0,
+				Id:         "task1",
+				Result:     42.0,
+				LeaseToken: "lease1",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "successfully submit exact task result",
+			setupMocks: func(repo *mocks.MockAgentRepository) {
+				repo.EXPECT().FinishTask(mock.Anything, models.FinishTaskCmd{
+					ID:          "task1",
+					Status:      models.TaskStatusCompleted,
+					Result:      0.3,
+					ExactResult: "3/10",
+				}).Return(nil)
+			},
+			req: &calculatorv1.SubmitTaskResultRequest{
+				Id:          "task1",
+				Result:      0.3,
+				ExactResult: "3/10",
 			},
 			wantErr: assert.NoError,
 		},
@@ -123,6 +240,21 @@ func TestAgentService_SubmitTaskResult(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "overflowed result fails the task",
+			setupMocks: func(repo *mocks.MockAgentRepository) {
+				repo.EXPECT().FinishTask(mock.Anything, models.FinishTaskCmd{
+					ID:     "task1",
+					Status: models.TaskStatusFailed,
+					Result: 0,
+				}).Return(nil)
+			},
+			req: &calculatorv1.SubmitTaskResultRequest{
+				Id:     "task1",
+				Result: math.Inf(1),
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "task not found",
 			setupMocks: func(repo *mocks.MockAgentRepository) {
@@ -134,6 +266,30 @@ func TestAgentService_SubmitTaskResult(t *testing.T) {
 			},
 			wantErr: assert.Error,
 		},
+		{
+			name: "task already finished",
+			setupMocks: func(repo *mocks.MockAgentRepository) {
+				repo.EXPECT().FinishTask(mock.Anything, mock.Anything).Return(models.ErrTaskNotInProgress)
+			},
+			req: &calculatorv1.SubmitTaskResultRequest{
+				Id:         "task1",
+				Result:     10.0,
+				LeaseToken: "lease1",
+			},
+			wantErr: errorHasCode(codes.FailedPrecondition),
+		},
+		{
+			name: "stale lease token",
+			setupMocks: func(repo *mocks.MockAgentRepository) {
+				repo.EXPECT().FinishTask(mock.Anything, mock.Anything).Return(models.ErrLeaseTokenMismatch)
+			},
+			req: &calculatorv1.SubmitTaskResultRequest{
+				Id:         "task1",
+				Result:     10.0,
+				LeaseToken: "lease0",
+			},
+			wantErr: errorHasCode(codes.FailedPrecondition),
+		},
 		{
 			name: "repository error",
 			setupMocks: func(repo *mocks.MockAgentRepository) {
@@ -18,18 +18,28 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type Calculator interface {
-	Parse(string) ([]calctypes.Token, error)
-	Schedule([]calctypes.Token) []calctypes.Task
+	Parse(string, map[string]float64, map[string]calctypes.Function) ([]calctypes.Token, error)
+	ParseInt(string, map[string]float64, map[string]calctypes.Function) ([]calctypes.Token, error)
+	ParseExact(string, map[string]float64, map[string]calctypes.Function) ([]calctypes.Token, error)
+	ParseFunction(string, map[string]calctypes.Function) (calctypes.Function, error)
+	CheckFunctionDeletion(string, map[string]calctypes.Function) error
+	UsedFunctions(string, map[string]float64, map[string]calctypes.Function) map[string]int
+	Schedule([]calctypes.Token) calctypes.Plan
+	Rebalance([]calctypes.Token) []calctypes.Token
+	Simplify([]calctypes.Token) ([]calctypes.Token, int)
 }
 
 type CalculatorRepository interface {
 	CreateExpression(context.Context, models.CreateExpressionCmd, []models.CreateExpressionTaskCmd) (string, error)
 	ListExpressions(context.Context) ([]models.Expression, error)
 	GetExpression(context.Context, string) (models.Expression, error)
+	CancelExpression(context.Context, string) (models.Expression, error)
+	ListFunctions(context.Context) ([]models.Function, error)
 }
 
 type CalculatorService struct {
@@ -61,29 +71,34 @@ func (s *CalculatorService) Calculate(
 	ctx context.Context,
 	req *calculatorv1.CalculateRequest,
 ) (*calculatorv1.CalculateResponse, error) {
-	parsed, err := s.calc.Parse(req.Expression)
+	arithmetic := mapArithmeticToModel(req.Arithmetic)
+	funcs, err := s.functions(ctx)
 	if err != nil {
-		if errors.Is(err, calctypes.ErrInvalidExpr) {
-			server.WithHTTPResponseCode(ctx, http.StatusUnprocessableEntity)
-			return nil, status.Error(codes.InvalidArgument, "invalid expression")
-		}
-		return nil, InternalError(fmt.Errorf("parse expression: %w", err))
+		return nil, err
+	}
+	prepared, err := s.prepare(ctx, req.Expression, req.Variables, funcs, arithmetic, req.Rebalance, req.Simplify)
+	if err != nil {
+		return nil, err
 	}
+	tasksSaved := prepared.TasksSaved
 
-	tasks := s.calc.Schedule(parsed)
+	plan := s.calc.Schedule(prepared.RPN)
 
-	createExpr := models.CreateExpressionCmd{Expression: req.Expression}
-	createTasks := make([]models.CreateExpressionTaskCmd, 0, len(tasks))
-	for _, t := range tasks {
-		createTasks = append(createTasks, models.CreateExpressionTaskCmd{
-			ID:            t.ID,
-			ParentTask1ID: t.ParentTask1ID,
-			ParentTask2ID: t.ParentTask2ID,
-			Arg1:          t.Arg1,
-			Arg2:          t.Arg2,
-			Operation:     s.mapTaskOperation(t.Operation),
-			OperationTime: s.getTaskOperationTime(t.Operation),
-		})
+	createExpr := models.CreateExpressionCmd{
+		Expression: req.Expression,
+		Result:     plan.Value,
+		Variables:  prepared.Variables,
+		Arithmetic: arithmetic,
+	}
+	if len(funcs) != 0 {
+		createExpr.Functions = s.calc.UsedFunctions(req.Expression, req.Variables, funcs)
+	}
+	if arithmetic != models.ArithmeticFloat {
+		createExpr.ExactResult = plan.Exact
+	}
+	createTasks, err := s.buildTasks(ctx, plan, arithmetic)
+	if err != nil {
+		return nil, err
 	}
 
 	id, err := s.repo.CreateExpression(ctx, createExpr, createTasks)
@@ -91,8 +106,10 @@ func (s *CalculatorService) Calculate(
 		return nil, InternalError(fmt.Errorf("create expression: %w", err))
 	}
 
+	simplifiedTasksTotal.Add(float64(tasksSaved))
+
 	server.WithHTTPResponseCode(ctx, http.StatusCreated)
-	return &calculatorv1.CalculateResponse{Id: id}, nil
+	return &calculatorv1.CalculateResponse{Id: id, TasksSaved: int32(tasksSaved)}, nil
 }
 
 func (s *CalculatorService) ListExpressions(ctx context.Context, _ *emptypb.Empty) (*calculatorv1.ListExpressionsResponse, error) {
@@ -125,6 +142,252 @@ func (s *CalculatorService) GetExpression(
 	}, nil
 }
 
+// CancelExpression stops calculation of the expression, discarding results of its tasks being processed.
+func (s *CalculatorService) CancelExpression(
+	ctx context.Context,
+	req *calculatorv1.CancelExpressionRequest,
+) (*calculatorv1.CancelExpressionResponse, error) {
+	expr, err := s.repo.CancelExpression(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, models.ErrExpressionNotFound) {
+			return nil, status.Error(codes.NotFound, "expression not found")
+		}
+		if errors.Is(err, models.ErrExpressionFinished) {
+			return nil, status.Error(codes.FailedPrecondition, "expression already finished")
+		}
+		return nil, InternalError(fmt.Errorf("cancel expression: %w", err))
+	}
+
+	return &calculatorv1.CancelExpressionResponse{
+		Expression: mapExpressionToExpressionResponse(expr),
+	}, nil
+}
+
+// ExplainExpression parses and schedules the expression the same way as Calculate, but doesn't store it.
+func (s *CalculatorService) ExplainExpression(
+	ctx context.Context,
+	req *calculatorv1.ExplainExpressionRequest,
+) (*calculatorv1.ExplainExpressionResponse, error) {
+	arithmetic := mapArithmeticToModel(req.Arithmetic)
+	funcs, err := s.functions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prepared, err := s.prepare(ctx, req.Expression, req.Variables, funcs, arithmetic, req.Rebalance, req.Simplify)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := s.calc.Schedule(prepared.RPN)
+
+	tasks, err := s.buildTasks(ctx, plan, arithmetic)
+	if err != nil {
+		return nil, err
+	}
+
+	pathLength, estimatedTime := criticalPath(tasks)
+	resp := &calculatorv1.ExplainExpressionResponse{
+		Rpn:                mapTokensToRPN(prepared.RPN),
+		Tasks:              make([]*calculatorv1.ExplainExpressionResponse_Task, 0, len(tasks)),
+		CriticalPathLength: int32(pathLength),
+		EstimatedTime:      durationpb.New(estimatedTime),
+		Value:              plan.Value,
+		TasksSaved:         int32(prepared.TasksSaved),
+	}
+	for _, t := range tasks {
+		resp.Tasks = append(resp.Tasks, mapTaskToExplainTaskResponse(t))
+	}
+	return resp, nil
+}
+
+// functions returns the current user-defined functions, which expressions may call.
+func (s *CalculatorService) functions(ctx context.Context) (map[string]calctypes.Function, error) {
+	funcs, err := s.repo.ListFunctions(ctx)
+	if err != nil {
+		return nil, InternalError(fmt.Errorf("list functions: %w", err))
+	}
+	return mapFunctionsToCalc(funcs), nil
+}
+
+// preparedExpression is an expression parsed, rebalanced and simplified as requested, ready to be scheduled.
+type preparedExpression struct {
+	RPN        []calctypes.Token
+	Variables  map[string]float64 // bound before simplification, which may fold them away
+	TasksSaved int
+}
+
+// prepare parses, rebalances and simplifies the expression as requested. Calculate and ExplainExpression
+// share it, so the explained plan is the one the expression would be calculated with.
+func (s *CalculatorService) prepare(
+	ctx context.Context,
+	expr string,
+	vars map[string]float64,
+	funcs map[string]calctypes.Function,
+	arithmetic models.Arithmetic,
+	rebalance, simplify *bool,
+) (preparedExpression, error) {
+	parsed, err := s.parse(ctx, expr, vars, funcs, arithmetic)
+	if err != nil {
+		return preparedExpression{}, err
+	}
+	if s.shouldRebalance(rebalance) {
+		parsed = s.calc.Rebalance(parsed)
+	}
+	prepared := preparedExpression{RPN: parsed, Variables: boundVariables(parsed)}
+	if arithmetic == models.ArithmeticFloat && s.shouldSimplify(simplify) { // folding is done in float64
+		prepared.RPN, prepared.TasksSaved = s.calc.Simplify(parsed)
+	}
+	return prepared, nil
+}
+
+// parse parses the expression for the arithmetic, errors are returned as statuses ready to be sent to the client.
+func (s *CalculatorService) parse(
+	ctx context.Context,
+	expr string,
+	vars map[string]float64,
+	funcs map[string]calctypes.Function,
+	arithmetic models.Arithmetic,
+) ([]calctypes.Token, error) {
+	parse := s.calc.Parse
+	switch arithmetic {
+	case models.ArithmeticExact:
+		parse = s.calc.ParseExact // rejects irrational constants
+	case models.ArithmeticBigInt:
+		parse = s.calc.ParseInt // rejects fractional numbers
+	}
+	parsed, err := parse(expr, vars, funcs)
+	if err != nil {
+		var parseErr *calctypes.ParseError
+		if errors.As(err, &parseErr) {
+			server.WithHTTPResponseCode(ctx, http.StatusUnprocessableEntity)
+			return nil, InvalidExpressionError(parseErr)
+		}
+		if errors.Is(err, calctypes.ErrInvalidExpr) {
+			server.WithHTTPResponseCode(ctx, http.StatusUnprocessableEntity)
+			return nil, status.Error(codes.InvalidArgument, "invalid expression")
+		}
+		if errors.Is(err, calctypes.ErrInvalidVariable) {
+			server.WithHTTPResponseCode(ctx, http.StatusUnprocessableEntity)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, InternalError(fmt.Errorf("parse expression: %w", err))
+	}
+	return parsed, nil
+}
+
+// shouldRebalance reports whether chains of associative operations have to be rebalanced,
+// the request's choice overrides the configured default.
+func (s *CalculatorService) shouldRebalance(rebalance *bool) bool {
+	if rebalance != nil {
+		return *rebalance
+	}
+	return s.conf.RebalanceExpressions
+}
+
+// shouldSimplify reports whether operations over literals and identities have to be eliminated,
+// the request's choice overrides the configured default.
+func (s *CalculatorService) shouldSimplify(simplify *bool) bool {
+	if simplify != nil {
+		return *simplify
+	}
+	return s.conf.SimplifyExpressions
+}
+
+// buildTasks converts the plan into tasks that agents can execute in the arithmetic.
+// Returns an InvalidArgument status if the plan contains an operation agents don't support.
+func (s *CalculatorService) buildTasks(
+	ctx context.Context,
+	plan calctypes.Plan,
+	arithmetic models.Arithmetic,
+) ([]models.CreateExpressionTaskCmd, error) {
+	tasks := make([]models.CreateExpressionTaskCmd, 0, len(plan.Tasks))
+	for _, t := range plan.Tasks {
+		op := s.mapTaskOperation(t.Operation)
+		if op == "" {
+			// Agents can't execute it, so the expression would never be completed
+			server.WithHTTPResponseCode(ctx, http.StatusUnprocessableEntity)
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported operation %q", t.Operation)
+		}
+		if arithmetic == models.ArithmeticExact && (op == models.TaskOperationSqrt || op == models.TaskOperationLog) {
+			// Results are irrational in general
+			server.WithHTTPResponseCode(ctx, http.StatusUnprocessableEntity)
+			return nil, status.Errorf(codes.InvalidArgument, "operation %q is not supported in exact arithmetic", t.Operation)
+		}
+		if arithmetic == models.ArithmeticBigInt && !isIntegerOperation(op) {
+			server.WithHTTPResponseCode(ctx, http.StatusUnprocessableEntity)
+			return nil, status.Errorf(codes.InvalidArgument, "operation %q is not supported in big integer arithmetic", t.Operation)
+		}
+		task := models.CreateExpressionTaskCmd{
+			ID:            t.ID,
+			ParentTask1ID: t.ParentTask1ID,
+			ParentTask2ID: t.ParentTask2ID,
+			ParentTaskIDs: t.ParentTaskIDs,
+			Arg1:          t.Arg1,
+			Arg2:          t.Arg2,
+			Args:          t.Args,
+			Operation:     op,
+			OperationTime: s.getTaskOperationTime(t.Operation),
+
+			ConditionTaskID: t.ConditionTaskID,
+			ConditionValue:  t.ConditionValue,
+		}
+		if arithmetic != models.ArithmeticFloat {
+			task.ExactArg1, task.ExactArg2, task.ExactArgs = t.ExactArg1, t.ExactArg2, t.ExactArgs
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// isIntegerOperation reports whether the operation over integers always results in an integer.
+func isIntegerOperation(op models.TaskOperation) bool {
+	switch op {
+	case models.TaskOperationDivision, models.TaskOperationSqrt, models.TaskOperationLog:
+		return false
+	default:
+		return true
+	}
+}
+
+// criticalPath returns the number of tasks in the longest chain of dependent tasks
+// and the time it takes to calculate all tasks if independent ones are calculated in parallel.
+// Tasks of a branch of a condition depend on it, though both branches are counted.
+// Tasks must follow their parents.
+func criticalPath(tasks []models.CreateExpressionTaskCmd) (int, time.Duration) {
+	length := make(map[string]int, len(tasks))
+	finishAt := make(map[string]time.Duration, len(tasks))
+
+	maxLength, maxFinishAt := 0, time.Duration(0)
+	for _, t := range tasks {
+		startAt := time.Duration(0)
+		for _, parentID := range append([]string{t.ParentTask1ID, t.ParentTask2ID, t.ConditionTaskID}, t.ParentTaskIDs...) {
+			length[t.ID] = max(length[t.ID], length[parentID])
+			startAt = max(startAt, finishAt[parentID])
+		}
+		length[t.ID]++
+		finishAt[t.ID] = startAt + t.OperationTime
+
+		maxLength = max(maxLength, length[t.ID])
+		maxFinishAt = max(maxFinishAt, finishAt[t.ID])
+	}
+	return maxLength, maxFinishAt
+}
+
+// boundVariables returns values of the constants and variables substituted into the expression.
+func boundVariables(rpn []calctypes.Token) map[string]float64 {
+	var vars map[string]float64
+	for _, t := range rpn {
+		if t.Name == "" {
+			continue
+		}
+		if vars == nil {
+			vars = map[string]float64{}
+		}
+		vars[t.Name] = t.Number
+	}
+	return vars
+}
+
 func (s *CalculatorService) mapTaskOperation(op string) models.TaskOperation {
 	switch op {
 	case "+":
@@ -135,6 +398,46 @@ func (s *CalculatorService) mapTaskOperation(op string) models.TaskOperation {
 		return models.TaskOperationMultiplication
 	case "/":
 		return models.TaskOperationDivision
+	case "neg":
+		return models.TaskOperationNegation
+	case "^":
+		return models.TaskOperationPower
+	case "%":
+		return models.TaskOperationModulo
+	case "//":
+		return models.TaskOperationIntegerDivision
+	case "sqrt":
+		return models.TaskOperationSqrt
+	case "abs":
+		return models.TaskOperationAbs
+	case "min":
+		return models.TaskOperationMin
+	case "max":
+		return models.TaskOperationMax
+	case "round":
+		return models.TaskOperationRound
+	case "log":
+		return models.TaskOperationLog
+	case "<":
+		return models.TaskOperationLess
+	case "<=":
+		return models.TaskOperationLessOrEqual
+	case ">":
+		return models.TaskOperationGreater
+	case ">=":
+		return models.TaskOperationGreaterOrEqual
+	case "==":
+		return models.TaskOperationEqual
+	case "!=":
+		return models.TaskOperationNotEqual
+	case "&&":
+		return models.TaskOperationAnd
+	case "||":
+		return models.TaskOperationOr
+	case "not":
+		return models.TaskOperationNot
+	case "if":
+		return models.TaskOperationIf
 	default:
 		return ""
 	}
@@ -151,6 +454,18 @@ func (s *CalculatorService) getTaskOperationTime(op string) time.Duration {
 		ms = s.conf.TimeMultiplicationMs
 	case "/":
 		ms = s.conf.TimeDivisionMs
+	case "neg":
+		ms = s.conf.TimeNegationMs
+	case "^":
+		ms = s.conf.TimePowerMs
+	case "%":
+		ms = s.conf.TimeModuloMs
+	case "//":
+		ms = s.conf.TimeIntegerDivisionMs
+	case "sqrt", "abs", "min", "max", "round", "log", "if":
+		ms = s.conf.TimeFunctionMs
+	case "<", "<=", ">", ">=", "==", "!=", "&&", "||", "not":
+		ms = s.conf.TimeComparisonMs
 	}
 	return time.Duration(ms) * time.Millisecond
 }
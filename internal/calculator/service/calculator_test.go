@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	calctypes "github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
 	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/config"
@@ -13,6 +15,10 @@ import (
 	calculatorv1 "github.com/belo4ya/edu-dist-calculate-api/pkg/calculator/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -38,7 +44,8 @@ func TestCalculatorService_Calculate(t *testing.T) {
 		{
 			name: "successful calculation",
 			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
-				calc.EXPECT().Parse("1+2*3").Return([]calctypes.Token{
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				calc.EXPECT().Parse("1+2*3", mock.Anything, mock.Anything).Return([]calctypes.Token{
 					calctypes.NewToken(1),
 					calctypes.NewToken(2),
 					calctypes.NewToken(3),
@@ -46,13 +53,13 @@ func TestCalculatorService_Calculate(t *testing.T) {
 					calctypes.NewToken("+"),
 				}, nil)
 
-				calc.EXPECT().Schedule(mock.Anything).Return([]calctypes.Task{
+				calc.EXPECT().Schedule(mock.Anything).Return(calctypes.Plan{Tasks: []calctypes.Task{
 					{ID: "task1", Arg1: 2, Arg2: 3, Operation: "*"},
 					{ID: "task2", ParentTask1ID: "task1", Arg1: 1, Operation: "+"},
-				})
+				}})
 
 				repo.EXPECT().CreateExpression(mock.Anything,
-					models.CreateExpressionCmd{Expression: "1+2*3"},
+					models.CreateExpressionCmd{Expression: "1+2*3", Arithmetic: models.ArithmeticFloat},
 					mock.MatchedBy(func(tasks []models.CreateExpressionTaskCmd) bool {
 						return len(tasks) == 2
 					})).Return("expr123", nil)
@@ -66,10 +73,119 @@ func TestCalculatorService_Calculate(t *testing.T) {
 			want:    &calculatorv1.CalculateResponse{Id: "expr123"},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "expression calling user-defined functions",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return([]models.Function{
+					{Name: "vat", Params: []string{"x"}, Body: "x * 1.2", Version: 2},
+					{Name: "net", Params: []string{"x"}, Body: "x / 1.2", Version: 1},
+				}, nil)
+				funcs := map[string]calctypes.Function{
+					"vat": {Name: "vat", Params: []string{"x"}, Body: "x * 1.2", Version: 2},
+					"net": {Name: "net", Params: []string{"x"}, Body: "x / 1.2", Version: 1},
+				}
+				calc.EXPECT().Parse("vat(10)", mock.Anything, funcs).Return([]calctypes.Token{
+					calctypes.NewToken(10),
+					calctypes.NewToken(1.2),
+					calctypes.NewToken("*"),
+				}, nil)
+				calc.EXPECT().UsedFunctions("vat(10)", mock.Anything, funcs).Return(map[string]int{"vat": 2})
+
+				calc.EXPECT().Schedule(mock.Anything).Return(calctypes.Plan{Tasks: []calctypes.Task{
+					{ID: "task1", Arg1: 10, Arg2: 1.2, Operation: "*"},
+				}})
+
+				repo.EXPECT().CreateExpression(mock.Anything,
+					models.CreateExpressionCmd{
+						Expression: "vat(10)",
+						Arithmetic: models.ArithmeticFloat,
+						Functions:  map[string]int{"vat": 2},
+					},
+					mock.Anything).Return("expr123", nil)
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "vat(10)",
+				},
+			},
+			want:    &calculatorv1.CalculateResponse{Id: "expr123"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "functions listing error",
+			setupMocks: func(_ *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, assert.AnError)
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "vat(10)",
+				},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "constant expression",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				calc.EXPECT().Parse("(7)", mock.Anything, mock.Anything).Return([]calctypes.Token{
+					calctypes.NewToken(7),
+				}, nil)
+
+				calc.EXPECT().Schedule(mock.Anything).Return(calctypes.Plan{Tasks: []calctypes.Task{}, Value: 7})
+
+				repo.EXPECT().CreateExpression(mock.Anything,
+					models.CreateExpressionCmd{Expression: "(7)", Result: 7, Arithmetic: models.ArithmeticFloat},
+					[]models.CreateExpressionTaskCmd{}).Return("expr123", nil)
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "(7)",
+				},
+			},
+			want:    &calculatorv1.CalculateResponse{Id: "expr123"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "expression with variables",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				calc.EXPECT().Parse("x*pi", map[string]float64{"x": 2, "unused": 3}, mock.Anything).Return([]calctypes.Token{
+					calctypes.NewVarToken("x", 2),
+					calctypes.NewVarToken("pi", 3.14),
+					calctypes.NewToken("*"),
+				}, nil)
+
+				calc.EXPECT().Schedule(mock.Anything).Return(calctypes.Plan{Tasks: []calctypes.Task{
+					{ID: "task1", Arg1: 2, Arg2: 3.14, Operation: "*"},
+				}})
+
+				repo.EXPECT().CreateExpression(mock.Anything,
+					models.CreateExpressionCmd{
+						Expression: "x*pi",
+						Variables:  map[string]float64{"x": 2, "pi": 3.14},
+						Arithmetic: models.ArithmeticFloat,
+					},
+					mock.Anything).Return("expr123", nil)
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "x*pi",
+					Variables:  map[string]float64{"x": 2, "unused": 3},
+				},
+			},
+			want:    &calculatorv1.CalculateResponse{Id: "expr123"},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "invalid expression",
 			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
-				calc.EXPECT().Parse("1++2").Return(nil, calctypes.ErrInvalidExpr)
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				calc.EXPECT().Parse("1++2", mock.Anything, mock.Anything).Return(nil, calctypes.ErrInvalidExpr)
 			},
 			args: args{
 				ctx: context.Background(),
@@ -80,10 +196,347 @@ func TestCalculatorService_Calculate(t *testing.T) {
 			want:    nil,
 			wantErr: assert.Error,
 		},
+		{
+			name: "rebalanced expression",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				parsed := []calctypes.Token{
+					calctypes.NewToken(1),
+					calctypes.NewToken(2),
+					calctypes.NewToken("+"),
+					calctypes.NewToken(3),
+					calctypes.NewToken("+"),
+				}
+				rebalanced := []calctypes.Token{
+					calctypes.NewToken(1),
+					calctypes.NewToken(2),
+					calctypes.NewToken(3),
+					calctypes.NewToken("+"),
+					calctypes.NewToken("+"),
+				}
+				calc.EXPECT().Parse("1+2+3", mock.Anything, mock.Anything).Return(parsed, nil)
+				calc.EXPECT().Rebalance(parsed).Return(rebalanced)
+
+				calc.EXPECT().Schedule(rebalanced).Return(calctypes.Plan{Tasks: []calctypes.Task{
+					{ID: "task1", Arg1: 2, Arg2: 3, Operation: "+"},
+					{ID: "task2", Arg1: 1, ParentTask2ID: "task1", Operation: "+"},
+				}})
+
+				repo.EXPECT().CreateExpression(mock.Anything, mock.Anything, mock.Anything).Return("expr123", nil)
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "1+2+3",
+					Rebalance:  proto.Bool(true),
+				},
+			},
+			want:    &calculatorv1.CalculateResponse{Id: "expr123"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "simplified expression",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				parsed := []calctypes.Token{
+					calctypes.NewVarToken("x", 5),
+					calctypes.NewToken(2),
+					calctypes.NewToken(3),
+					calctypes.NewToken("-"),
+					calctypes.NewToken("*"),
+				}
+				simplified := []calctypes.Token{
+					calctypes.NewVarToken("x", 5),
+				}
+				calc.EXPECT().Parse("x*(3-2)", mock.Anything, mock.Anything).Return(parsed, nil)
+				calc.EXPECT().Simplify(parsed).Return(simplified, 2)
+
+				calc.EXPECT().Schedule(simplified).Return(calctypes.Plan{Tasks: []calctypes.Task{}, Value: 5})
+
+				repo.EXPECT().CreateExpression(mock.Anything, models.CreateExpressionCmd{
+					Expression: "x*(3-2)",
+					Result:     5,
+					Variables:  map[string]float64{"x": 5},
+					Arithmetic: models.ArithmeticFloat,
+				}, []models.CreateExpressionTaskCmd{}).Return("expr123", nil)
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "x*(3-2)",
+					Variables:  map[string]float64{"x": 5},
+					Simplify:   proto.Bool(true),
+				},
+			},
+			want:    &calculatorv1.CalculateResponse{Id: "expr123", TasksSaved: 2},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "exact arithmetic",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				parsed := []calctypes.Token{
+					calctypes.NewToken(0.1),
+					calctypes.NewToken(0.2),
+					calctypes.NewToken("+"),
+					calctypes.NewToken(3),
+					calctypes.NewFuncToken("max", 2),
+				}
+				calc.EXPECT().ParseExact("max(0.1+0.2, 3)", mock.Anything, mock.Anything).Return(parsed, nil)
+				calc.EXPECT().Schedule(parsed).Return(calctypes.Plan{Tasks: []calctypes.Task{
+					{ID: "task1", Arg1: 0.1, Arg2: 0.2, ExactArg1: "1/10", ExactArg2: "1/5", Operation: "+"},
+					{
+						ID:            "task2",
+						ParentTaskIDs: []string{"task1", ""},
+						Args:          []float64{0, 3},
+						ExactArgs:     []string{"", "3"},
+						Operation:     "max",
+					},
+				}})
+
+				repo.EXPECT().CreateExpression(mock.Anything, models.CreateExpressionCmd{
+					Expression: "max(0.1+0.2, 3)",
+					Arithmetic: models.ArithmeticExact,
+				}, []models.CreateExpressionTaskCmd{
+					{
+						ID:            "task1",
+						Arg1:          0.1,
+						Arg2:          0.2,
+						ExactArg1:     "1/10",
+						ExactArg2:     "1/5",
+						Operation:     models.TaskOperationAddition,
+						OperationTime: time.Second,
+					},
+					{
+						ID:            "task2",
+						ParentTaskIDs: []string{"task1", ""},
+						Args:          []float64{0, 3},
+						ExactArgs:     []string{"", "3"},
+						Operation:     models.TaskOperationMax,
+					},
+				}).Return("expr123", nil)
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "max(0.1+0.2, 3)",
+					Arithmetic: calculatorv1.Arithmetic_ARITHMETIC_EXACT,
+					Simplify:   proto.Bool(true), // ignored
+				},
+			},
+			want:    &calculatorv1.CalculateResponse{Id: "expr123"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "operation unsupported in exact arithmetic",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				parsed := []calctypes.Token{calctypes.NewToken(2), calctypes.NewFuncToken("sqrt", 1)}
+				calc.EXPECT().ParseExact("sqrt(2)", mock.Anything, mock.Anything).Return(parsed, nil)
+				calc.EXPECT().Schedule(parsed).Return(calctypes.Plan{Tasks: []calctypes.Task{
+					{ID: "task1", ParentTaskIDs: []string{""}, Args: []float64{2}, ExactArgs: []string{"2"}, Operation: "sqrt"},
+				}})
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "sqrt(2)",
+					Arithmetic: calculatorv1.Arithmetic_ARITHMETIC_EXACT,
+				},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.Equal(t, codes.InvalidArgument, status.Code(err), msgAndArgs...) &&
+					assert.ErrorContains(t, err, "not supported in exact arithmetic", msgAndArgs...)
+			},
+		},
+		{
+			name: "non-finite variable",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				calc.EXPECT().Parse("x + 1", map[string]float64{"x": math.Inf(1)}, mock.Anything).
+					Return(nil, fmt.Errorf("%w: \"x\" must be finite, got +Inf", calctypes.ErrInvalidVariable))
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "x + 1",
+					Variables:  map[string]float64{"x": math.Inf(1)},
+				},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.Equal(t, codes.InvalidArgument, status.Code(err), msgAndArgs...) &&
+					assert.ErrorContains(t, err, `"x" must be finite`, msgAndArgs...)
+			},
+		},
+		{
+			name: "irrational constant in exact arithmetic",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				calc.EXPECT().ParseExact("2 * pi", mock.Anything, mock.Anything).Return(nil, &calctypes.ParseError{
+					Offset: 4,
+					Token:  "pi",
+					Reason: calctypes.ReasonInvalidNumber,
+					Detail: "irrational constant",
+				})
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "2 * pi",
+					Arithmetic: calculatorv1.Arithmetic_ARITHMETIC_EXACT,
+				},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.Equal(t, codes.InvalidArgument, status.Code(err), msgAndArgs...) &&
+					assert.ErrorContains(t, err, "irrational constant", msgAndArgs...)
+			},
+		},
+		{
+			name: "big integer arithmetic",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				parsed := []calctypes.Token{
+					calctypes.NewExactToken(9007199254740993, "9007199254740993"),
+					calctypes.NewToken(3),
+					calctypes.NewToken("^"),
+				}
+				calc.EXPECT().ParseInt("9007199254740993 ^ 3", mock.Anything, mock.Anything).Return(parsed, nil)
+				calc.EXPECT().Schedule(parsed).Return(calctypes.Plan{Tasks: []calctypes.Task{
+					{ID: "task1", Arg1: 9007199254740993, Arg2: 3, ExactArg1: "9007199254740993", ExactArg2: "3", Operation: "^"},
+				}})
+
+				repo.EXPECT().CreateExpression(mock.Anything, models.CreateExpressionCmd{
+					Expression: "9007199254740993 ^ 3",
+					Arithmetic: models.ArithmeticBigInt,
+				}, []models.CreateExpressionTaskCmd{
+					{
+						ID:        "task1",
+						Arg1:      9007199254740993,
+						Arg2:      3,
+						ExactArg1: "9007199254740993",
+						ExactArg2: "3",
+						Operation: models.TaskOperationPower,
+					},
+				}).Return("expr123", nil)
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "9007199254740993 ^ 3",
+					Arithmetic: calculatorv1.Arithmetic_ARITHMETIC_BIG_INT,
+				},
+			},
+			want:    &calculatorv1.CalculateResponse{Id: "expr123"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "fractional literal in big integer arithmetic",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				calc.EXPECT().ParseInt("2 * 1.5", mock.Anything, mock.Anything).Return(nil, &calctypes.ParseError{
+					Offset: 4,
+					Token:  "1.5",
+					Reason: calctypes.ReasonInvalidNumber,
+					Detail: "not an integer",
+				})
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "2 * 1.5",
+					Arithmetic: calculatorv1.Arithmetic_ARITHMETIC_BIG_INT,
+				},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.Equal(t, codes.InvalidArgument, status.Code(err), msgAndArgs...) &&
+					assert.ErrorContains(t, err, "not an integer", msgAndArgs...)
+			},
+		},
+		{
+			name: "operation unsupported in big integer arithmetic",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				parsed := []calctypes.Token{calctypes.NewToken(7), calctypes.NewToken(2), calctypes.NewToken("/")}
+				calc.EXPECT().ParseInt("7 / 2", mock.Anything, mock.Anything).Return(parsed, nil)
+				calc.EXPECT().Schedule(parsed).Return(calctypes.Plan{Tasks: []calctypes.Task{
+					{ID: "task1", Arg1: 7, Arg2: 2, ExactArg1: "7", ExactArg2: "2", Operation: "/"},
+				}})
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "7 / 2",
+					Arithmetic: calculatorv1.Arithmetic_ARITHMETIC_BIG_INT,
+				},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.Equal(t, codes.InvalidArgument, status.Code(err), msgAndArgs...) &&
+					assert.ErrorContains(t, err, "not supported in big integer arithmetic", msgAndArgs...)
+			},
+		},
+		{
+			name: "invalid expression with parse error details",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				calc.EXPECT().Parse("2 $ 3", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("tokenize: %w", &calctypes.ParseError{
+					Offset: 2,
+					Token:  "$",
+					Reason: calctypes.ReasonUnknownCharacter,
+				}))
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "2 $ 3",
+				},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				st := status.Convert(err)
+				return assert.Equal(t, codes.InvalidArgument, st.Code(), msgAndArgs...) &&
+					assert.Len(t, st.Details(), 1, msgAndArgs...) &&
+					assert.True(t, proto.Equal(&calculatorv1.ParseError{
+						Offset: 2,
+						Token:  "$",
+						Reason: calculatorv1.ParseErrorReason_PARSE_ERROR_REASON_UNKNOWN_CHARACTER,
+					}, st.Details()[0].(proto.Message)), msgAndArgs...)
+			},
+		},
+		{
+			name: "unsupported operation",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				calc.EXPECT().Parse("2 $ 3", mock.Anything, mock.Anything).Return([]calctypes.Token{
+					calctypes.NewToken(2),
+					calctypes.NewToken(3),
+					calctypes.NewToken("$"),
+				}, nil)
+
+				calc.EXPECT().Schedule(mock.Anything).Return(calctypes.Plan{Tasks: []calctypes.Task{
+					{ID: "task1", Arg1: 2, Arg2: 3, Operation: "$"},
+				}})
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &calculatorv1.CalculateRequest{
+					Expression: "2 $ 3",
+				},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.Equal(t, codes.InvalidArgument, status.Code(err), msgAndArgs...)
+			},
+		},
 		{
 			name: "parse error",
 			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
-				calc.EXPECT().Parse(mock.Anything).Return(nil, assert.AnError)
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				calc.EXPECT().Parse(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
 			},
 			args: args{
 				ctx: context.Background(),
@@ -97,15 +550,16 @@ func TestCalculatorService_Calculate(t *testing.T) {
 		{
 			name: "repository error",
 			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
-				calc.EXPECT().Parse("1+2").Return([]calctypes.Token{
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+				calc.EXPECT().Parse("1+2", mock.Anything, mock.Anything).Return([]calctypes.Token{
 					calctypes.NewToken("+"),
 					calctypes.NewToken(1),
 					calctypes.NewToken(2),
 				}, nil)
 
-				calc.EXPECT().Schedule(mock.Anything).Return([]calctypes.Task{
+				calc.EXPECT().Schedule(mock.Anything).Return(calctypes.Plan{Tasks: []calctypes.Task{
 					{ID: "task1", Arg1: 1, Arg2: 2, Operation: "+"},
-				})
+				}})
 
 				repo.EXPECT().CreateExpression(mock.Anything, mock.Anything, mock.Anything).
 					Return("", assert.AnError)
@@ -275,6 +729,118 @@ func TestCalculatorService_GetExpression(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "expression with functions found",
+			setupMocks: func(_ *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().GetExpression(mock.Anything, "expr4").Return(models.Expression{
+					ID:         "expr4",
+					Expression: "vat(10)",
+					Status:     models.ExpressionStatusCompleted,
+					Result:     12,
+					Functions:  map[string]int{"vat": 2},
+				}, nil)
+			},
+			args: args{
+				req: &calculatorv1.GetExpressionRequest{
+					Id: "expr4",
+				},
+			},
+			want: &calculatorv1.GetExpressionResponse{
+				Expression: &calculatorv1.Expression{
+					Id:         "expr4",
+					Expression: "vat(10)",
+					Status:     calculatorv1.ExpressionStatus_EXPRESSION_STATUS_COMPLETED,
+					Result:     12,
+					Functions:  map[string]int32{"vat": 2},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "expression with variables found",
+			setupMocks: func(_ *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().GetExpression(mock.Anything, "expr3").Return(models.Expression{
+					ID:         "expr3",
+					Expression: "x*2",
+					Status:     models.ExpressionStatusCompleted,
+					Result:     6,
+					Variables:  map[string]float64{"x": 3},
+				}, nil)
+			},
+			args: args{
+				req: &calculatorv1.GetExpressionRequest{
+					Id: "expr3",
+				},
+			},
+			want: &calculatorv1.GetExpressionResponse{
+				Expression: &calculatorv1.Expression{
+					Id:         "expr3",
+					Expression: "x*2",
+					Status:     calculatorv1.ExpressionStatus_EXPRESSION_STATUS_COMPLETED,
+					Result:     6,
+					Variables:  map[string]float64{"x": 3},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "exact expression found",
+			setupMocks: func(_ *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().GetExpression(mock.Anything, "expr4").Return(models.Expression{
+					ID:          "expr4",
+					Expression:  "0.1+0.2",
+					Status:      models.ExpressionStatusCompleted,
+					Result:      0.3,
+					Arithmetic:  models.ArithmeticExact,
+					ExactResult: "3/10",
+				}, nil)
+			},
+			args: args{
+				req: &calculatorv1.GetExpressionRequest{
+					Id: "expr4",
+				},
+			},
+			want: &calculatorv1.GetExpressionResponse{
+				Expression: &calculatorv1.Expression{
+					Id:          "expr4",
+					Expression:  "0.1+0.2",
+					Status:      calculatorv1.ExpressionStatus_EXPRESSION_STATUS_COMPLETED,
+					Result:      0.3,
+					Arithmetic:  calculatorv1.Arithmetic_ARITHMETIC_EXACT,
+					ExactResult: "0.3",
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "exact expression with infinite decimal found",
+			setupMocks: func(_ *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().GetExpression(mock.Anything, "expr5").Return(models.Expression{
+					ID:          "expr5",
+					Expression:  "1/3",
+					Status:      models.ExpressionStatusCompleted,
+					Result:      1.0 / 3,
+					Arithmetic:  models.ArithmeticExact,
+					ExactResult: "1/3",
+				}, nil)
+			},
+			args: args{
+				req: &calculatorv1.GetExpressionRequest{
+					Id: "expr5",
+				},
+			},
+			want: &calculatorv1.GetExpressionResponse{
+				Expression: &calculatorv1.Expression{
+					Id:          "expr5",
+					Expression:  "1/3",
+					Status:      calculatorv1.ExpressionStatus_EXPRESSION_STATUS_COMPLETED,
+					Result:      1.0 / 3,
+					Arithmetic:  calculatorv1.Arithmetic_ARITHMETIC_EXACT,
+					ExactResult: "1/3",
+				},
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "expression not found",
 			setupMocks: func(_ *mocks.MockCalculator, repo *mocks.MockCalculatorRepository) {
@@ -320,3 +886,348 @@ func TestCalculatorService_GetExpression(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculatorService_CancelExpression(t *testing.T) {
+	errorHasCode := func(code codes.Code) assert.ErrorAssertionFunc {
+		return func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+			return assert.Equal(t, code, status.Code(err), msgAndArgs...)
+		}
+	}
+
+	type args struct {
+		req *calculatorv1.CancelExpressionRequest
+	}
+	tests := []struct {
+		name       string
+		setupMocks func(repo *mocks.MockCalculatorRepository)
+		args       args
+		want       *calculatorv1.CancelExpressionResponse
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "in-progress expression cancelled",
+			setupMocks: func(repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().CancelExpression(mock.Anything, "expr1").Return(models.Expression{
+					ID:         "expr1",
+					Expression: "2^64*3",
+					Status:     models.ExpressionStatusCancelled,
+				}, nil)
+			},
+			args: args{req: &calculatorv1.CancelExpressionRequest{Id: "expr1"}},
+			want: &calculatorv1.CancelExpressionResponse{
+				Expression: &calculatorv1.Expression{
+					Id:         "expr1",
+					Expression: "2^64*3",
+					Status:     calculatorv1.ExpressionStatus_EXPRESSION_STATUS_CANCELLED,
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "expression already finished",
+			setupMocks: func(repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().CancelExpression(mock.Anything, "expr2").Return(models.Expression{}, models.ErrExpressionFinished)
+			},
+			args:    args{req: &calculatorv1.CancelExpressionRequest{Id: "expr2"}},
+			wantErr: errorHasCode(codes.FailedPrecondition),
+		},
+		{
+			name: "expression not found",
+			setupMocks: func(repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().CancelExpression(mock.Anything, "non-existent").Return(models.Expression{}, models.ErrExpressionNotFound)
+			},
+			args:    args{req: &calculatorv1.CancelExpressionRequest{Id: "non-existent"}},
+			wantErr: errorHasCode(codes.NotFound),
+		},
+		{
+			name: "repository error",
+			setupMocks: func(repo *mocks.MockCalculatorRepository) {
+				repo.EXPECT().CancelExpression(mock.Anything, mock.Anything).Return(models.Expression{}, assert.AnError)
+			},
+			args:    args{req: &calculatorv1.CancelExpressionRequest{Id: "expr3"}},
+			wantErr: errorHasCode(codes.Internal),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := mocks.NewMockCalculatorRepository(t)
+
+			tt.setupMocks(repo)
+			svc := NewCalculatorService(&config.Config{}, testutil.DiscardLogger(), mocks.NewMockCalculator(t), repo)
+
+			got, err := svc.CancelExpression(ctx, tt.args.req)
+			if !tt.wantErr(t, err, fmt.Sprintf("CancelExpression(%v, %v)", ctx, tt.args.req)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "CancelExpression(%v, %v)", ctx, tt.args.req)
+		})
+	}
+}
+
+func TestCalculatorService_ExplainExpression(t *testing.T) {
+	conf := &config.Config{
+		TimeAdditionMs:       1000,
+		TimeSubtractionMs:    1000,
+		TimeMultiplicationMs: 2000,
+		TimeDivisionMs:       1000,
+	}
+
+	type args struct {
+		req *calculatorv1.ExplainExpressionRequest
+	}
+	tests := []struct {
+		name       string
+		setupMocks func(calc *mocks.MockCalculator)
+		args       args
+		want       *calculatorv1.ExplainExpressionResponse
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "sequential tasks",
+			setupMocks: func(calc *mocks.MockCalculator) {
+				calc.EXPECT().Parse("1+2*x", map[string]float64{"x": 3}, mock.Anything).Return([]calctypes.Token{
+					calctypes.NewToken(1),
+					calctypes.NewToken(2),
+					calctypes.NewVarToken("x", 3),
+					calctypes.NewToken("*"),
+					calctypes.NewToken("+"),
+				}, nil)
+
+				calc.EXPECT().Schedule(mock.Anything).Return(calctypes.Plan{Tasks: []calctypes.Task{
+					{ID: "task1", Arg1: 2, Arg2: 3, Operation: "*"},
+					{ID: "task2", ParentTask2ID: "task1", Arg1: 1, Operation: "+"},
+				}})
+			},
+			args: args{
+				req: &calculatorv1.ExplainExpressionRequest{
+					Expression: "1+2*x",
+					Variables:  map[string]float64{"x": 3},
+				},
+			},
+			want: &calculatorv1.ExplainExpressionResponse{
+				Rpn: []string{"1", "2", "x", "*", "+"},
+				Tasks: []*calculatorv1.ExplainExpressionResponse_Task{
+					{
+						Id:            "task1",
+						Arg_1:         2,
+						Arg_2:         3,
+						Operation:     calculatorv1.TaskOperation_TASK_OPERATION_MULTIPLICATION,
+						OperationTime: durationpb.New(2 * time.Second),
+					},
+					{
+						Id:             "task2",
+						ParentTask_2Id: "task1",
+						Arg_1:          1,
+						Operation:      calculatorv1.TaskOperation_TASK_OPERATION_ADDITION,
+						OperationTime:  durationpb.New(time.Second),
+					},
+				},
+				CriticalPathLength: 2,
+				EstimatedTime:      durationpb.New(3 * time.Second),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "parallel tasks",
+			setupMocks: func(calc *mocks.MockCalculator) {
+				calc.EXPECT().Parse("max(1+2, 3*4) - 5", mock.Anything, mock.Anything).Return([]calctypes.Token{
+					calctypes.NewToken(1),
+					calctypes.NewToken(2),
+					calctypes.NewToken("+"),
+					calctypes.NewToken(3),
+					calctypes.NewToken(4),
+					calctypes.NewToken("*"),
+					calctypes.NewFuncToken("max", 2),
+					calctypes.NewToken(5),
+					calctypes.NewToken("-"),
+				}, nil)
+
+				calc.EXPECT().Schedule(mock.Anything).Return(calctypes.Plan{Tasks: []calctypes.Task{
+					{ID: "task1", Arg1: 1, Arg2: 2, Operation: "+"},
+					{ID: "task2", Arg1: 3, Arg2: 4, Operation: "*"},
+					{ID: "task3", ParentTaskIDs: []string{"task1", "task2"}, Args: []float64{0, 0}, Operation: "max"},
+					{ID: "task4", ParentTask1ID: "task3", Arg2: 5, Operation: "-"},
+				}})
+			},
+			args: args{
+				req: &calculatorv1.ExplainExpressionRequest{Expression: "max(1+2, 3*4) - 5"},
+			},
+			want: &calculatorv1.ExplainExpressionResponse{
+				Rpn: []string{"1", "2", "+", "3", "4", "*", "max/2", "5", "-"},
+				Tasks: []*calculatorv1.ExplainExpressionResponse_Task{
+					{
+						Id:            "task1",
+						Arg_1:         1,
+						Arg_2:         2,
+						Operation:     calculatorv1.TaskOperation_TASK_OPERATION_ADDITION,
+						OperationTime: durationpb.New(time.Second),
+					},
+					{
+						Id:            "task2",
+						Arg_1:         3,
+						Arg_2:         4,
+						Operation:     calculatorv1.TaskOperation_TASK_OPERATION_MULTIPLICATION,
+						OperationTime: durationpb.New(2 * time.Second),
+					},
+					{
+						Id:            "task3",
+						Operation:     calculatorv1.TaskOperation_TASK_OPERATION_MAX,
+						OperationTime: durationpb.New(0),
+						ParentTaskIds: []string{"task1", "task2"},
+						Args:          []float64{0, 0},
+					},
+					{
+						Id:             "task4",
+						ParentTask_1Id: "task3",
+						Arg_2:          5,
+						Operation:      calculatorv1.TaskOperation_TASK_OPERATION_SUBTRACTION,
+						OperationTime:  durationpb.New(time.Second),
+					},
+				},
+				CriticalPathLength: 3,
+				EstimatedTime:      durationpb.New(3 * time.Second),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "constant expression",
+			setupMocks: func(calc *mocks.MockCalculator) {
+				calc.EXPECT().Parse("pi", mock.Anything, mock.Anything).Return([]calctypes.Token{
+					calctypes.NewVarToken("pi", 3.14),
+				}, nil)
+
+				calc.EXPECT().Schedule(mock.Anything).Return(calctypes.Plan{Tasks: []calctypes.Task{}, Value: 3.14})
+			},
+			args: args{
+				req: &calculatorv1.ExplainExpressionRequest{Expression: "pi"},
+			},
+			want: &calculatorv1.ExplainExpressionResponse{
+				Rpn:           []string{"pi"},
+				Tasks:         []*calculatorv1.ExplainExpressionResponse_Task{},
+				EstimatedTime: durationpb.New(0),
+				Value:         3.14,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "simplified expression",
+			setupMocks: func(calc *mocks.MockCalculator) {
+				parsed := []calctypes.Token{
+					calctypes.NewToken(1),
+					calctypes.NewToken(2),
+					calctypes.NewToken("+"),
+					calctypes.NewVarToken("x", 3),
+					calctypes.NewToken("*"),
+				}
+				simplified := []calctypes.Token{
+					calctypes.NewToken(3),
+					calctypes.NewVarToken("x", 3),
+					calctypes.NewToken("*"),
+				}
+				calc.EXPECT().Parse("(1+2)*x", mock.Anything, mock.Anything).Return(parsed, nil)
+				calc.EXPECT().Simplify(parsed).Return(simplified, 1)
+
+				calc.EXPECT().Schedule(simplified).Return(calctypes.Plan{Tasks: []calctypes.Task{
+					{ID: "task1", Arg1: 3, Arg2: 3, Operation: "*"},
+				}})
+			},
+			args: args{
+				req: &calculatorv1.ExplainExpressionRequest{
+					Expression: "(1+2)*x",
+					Variables:  map[string]float64{"x": 3},
+					Simplify:   proto.Bool(true),
+				},
+			},
+			want: &calculatorv1.ExplainExpressionResponse{
+				Rpn: []string{"3", "x", "*"},
+				Tasks: []*calculatorv1.ExplainExpressionResponse_Task{
+					{
+						Id:            "task1",
+						Arg_1:         3,
+						Arg_2:         3,
+						Operation:     calculatorv1.TaskOperation_TASK_OPERATION_MULTIPLICATION,
+						OperationTime: durationpb.New(2 * time.Second),
+					},
+				},
+				CriticalPathLength: 1,
+				EstimatedTime:      durationpb.New(2 * time.Second),
+				TasksSaved:         1,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "big integer expression is not simplified",
+			setupMocks: func(calc *mocks.MockCalculator) {
+				parsed := []calctypes.Token{
+					calctypes.NewToken(1),
+					calctypes.NewToken(2),
+					calctypes.NewToken("+"),
+				}
+				calc.EXPECT().ParseInt("1+2", mock.Anything, mock.Anything).Return(parsed, nil)
+
+				calc.EXPECT().Schedule(parsed).Return(calctypes.Plan{Tasks: []calctypes.Task{
+					{ID: "task1", Arg1: 1, Arg2: 2, ExactArg1: "1", ExactArg2: "2", Operation: "+"},
+				}})
+			},
+			args: args{
+				req: &calculatorv1.ExplainExpressionRequest{
+					Expression: "1+2",
+					Simplify:   proto.Bool(true),
+					Arithmetic: calculatorv1.Arithmetic_ARITHMETIC_BIG_INT,
+				},
+			},
+			want: &calculatorv1.ExplainExpressionResponse{
+				Rpn: []string{"1", "2", "+"},
+				Tasks: []*calculatorv1.ExplainExpressionResponse_Task{
+					{
+						Id:            "task1",
+						Arg_1:         1,
+						Arg_2:         2,
+						Operation:     calculatorv1.TaskOperation_TASK_OPERATION_ADDITION,
+						OperationTime: durationpb.New(time.Second),
+					},
+				},
+				CriticalPathLength: 1,
+				EstimatedTime:      durationpb.New(time.Second),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "invalid expression",
+			setupMocks: func(calc *mocks.MockCalculator) {
+				calc.EXPECT().Parse("1+", mock.Anything, mock.Anything).Return(nil, &calctypes.ParseError{
+					Offset: 1,
+					Token:  "+",
+					Reason: calctypes.ReasonDanglingOperator,
+				})
+			},
+			args: args{
+				req: &calculatorv1.ExplainExpressionRequest{Expression: "1+"},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.Equal(t, codes.InvalidArgument, status.Code(err), msgAndArgs...)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			calc := mocks.NewMockCalculator(t)
+			repo := mocks.NewMockCalculatorRepository(t)
+			repo.EXPECT().ListFunctions(mock.Anything).Return(nil, nil)
+
+			tt.setupMocks(calc)
+			svc := NewCalculatorService(conf, testutil.DiscardLogger(), calc, repo)
+
+			got, err := svc.ExplainExpression(ctx, tt.args.req)
+			if !tt.wantErr(t, err, fmt.Sprintf("ExplainExpression(%v, %v)", ctx, tt.args.req)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "ExplainExpression(%v, %v)", ctx, tt.args.req)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	calctypes "github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -8,3 +9,12 @@ import (
 func InternalError(err error) error {
 	return status.Errorf(codes.Internal, "oops, something went wrong: %s", err.Error())
 }
+
+// InvalidExpressionError returns an InvalidArgument status with calculatorv1.ParseError in its details.
+func InvalidExpressionError(err *calctypes.ParseError) error {
+	st, detailsErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(mapParseError(err))
+	if detailsErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
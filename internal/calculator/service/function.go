@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	calctypes "github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/config"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/repository/models"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/server"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/logging"
+	calculatorv1 "github.com/belo4ya/edu-dist-calculate-api/pkg/calculator/v1"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type FunctionRepository interface {
+	CreateFunction(context.Context, func([]models.Function) (models.CreateFunctionCmd, error)) (models.Function, error)
+	ListFunctions(context.Context) ([]models.Function, error)
+	DeleteFunction(context.Context, string, func([]models.Function) error) error
+}
+
+type FunctionService struct {
+	calculatorv1.UnimplementedFunctionServiceServer
+	conf *config.Config
+	log  *slog.Logger
+	calc Calculator
+	repo FunctionRepository
+}
+
+func NewFunctionService(conf *config.Config, log *slog.Logger, calc Calculator, repo FunctionRepository) *FunctionService {
+	return &FunctionService{
+		conf: conf,
+		log:  logging.WithName(log, "function-service"),
+		calc: calc,
+		repo: repo,
+	}
+}
+
+func (s *FunctionService) Register(srv *grpc.Server) {
+	calculatorv1.RegisterFunctionServiceServer(srv, s)
+}
+
+func (s *FunctionService) RegisterGRPCGateway(ctx context.Context, mux *runtime.ServeMux, clientOpts []grpc.DialOption) error {
+	return calculatorv1.RegisterFunctionServiceHandlerFromEndpoint(ctx, mux, "localhost"+s.conf.GRPCAddr, clientOpts)
+}
+
+// CreateFunction checks that the function can be expanded along with the current functions,
+// as can the functions calling it, and stores its new version.
+func (s *FunctionService) CreateFunction(
+	ctx context.Context,
+	req *calculatorv1.CreateFunctionRequest,
+) (*calculatorv1.CreateFunctionResponse, error) {
+	// The function is checked against the functions it is stored along with, so concurrent changes can't break it
+	created, err := s.repo.CreateFunction(ctx, func(funcs []models.Function) (models.CreateFunctionCmd, error) {
+		f, err := s.calc.ParseFunction(req.Definition, mapFunctionsToCalc(funcs))
+		if err != nil {
+			return models.CreateFunctionCmd{}, err
+		}
+		return models.CreateFunctionCmd{Name: f.Name, Params: f.Params, Body: f.Body}, nil
+	})
+	if err != nil {
+		var parseErr *calctypes.ParseError
+		if errors.As(err, &parseErr) {
+			server.WithHTTPResponseCode(ctx, http.StatusUnprocessableEntity)
+			return nil, InvalidExpressionError(parseErr)
+		}
+		if errors.Is(err, calctypes.ErrFunctionInUse) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, calctypes.ErrInvalidFunction) {
+			server.WithHTTPResponseCode(ctx, http.StatusUnprocessableEntity)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, InternalError(fmt.Errorf("create function: %w", err))
+	}
+
+	server.WithHTTPResponseCode(ctx, http.StatusCreated)
+	return &calculatorv1.CreateFunctionResponse{Function: mapFunctionToFunctionResponse(created)}, nil
+}
+
+func (s *FunctionService) ListFunctions(ctx context.Context, _ *emptypb.Empty) (*calculatorv1.ListFunctionsResponse, error) {
+	funcs, err := s.repo.ListFunctions(ctx)
+	if err != nil {
+		return nil, InternalError(fmt.Errorf("list functions: %w", err))
+	}
+
+	resp := &calculatorv1.ListFunctionsResponse{Functions: make([]*calculatorv1.Function, 0, len(funcs))}
+	for _, f := range funcs {
+		resp.Functions = append(resp.Functions, mapFunctionToFunctionResponse(f))
+	}
+	return resp, nil
+}
+
+// DeleteFunction deletes the function unless other functions call it.
+func (s *FunctionService) DeleteFunction(ctx context.Context, req *calculatorv1.DeleteFunctionRequest) (*emptypb.Empty, error) {
+	err := s.repo.DeleteFunction(ctx, req.Name, func(funcs []models.Function) error {
+		return s.calc.CheckFunctionDeletion(req.Name, mapFunctionsToCalc(funcs))
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrFunctionNotFound) {
+			return nil, status.Error(codes.NotFound, "function not found")
+		}
+		if errors.Is(err, calctypes.ErrFunctionInUse) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, InternalError(fmt.Errorf("delete function: %w", err))
+	}
+	return &emptypb.Empty{}, nil
+}
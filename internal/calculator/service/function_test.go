@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	calctypes "github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/config"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/repository/models"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/testutil"
+	mocks "github.com/belo4ya/edu-dist-calculate-api/internal/testutil/mocks/calculator/service"
+	calculatorv1 "github.com/belo4ya/edu-dist-calculate-api/pkg/calculator/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFunctionService_CreateFunction(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	errorHasCode := func(code codes.Code) assert.ErrorAssertionFunc {
+		return func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+			return assert.Equal(t, code, status.Code(err), msgAndArgs...)
+		}
+	}
+	// defineAmong makes the repository define the function among the current funcs and store it as the version
+	defineAmong := func(
+		funcs []models.Function,
+		version int,
+	) func(context.Context, func([]models.Function) (models.CreateFunctionCmd, error)) (models.Function, error) {
+		return func(_ context.Context, define func([]models.Function) (models.CreateFunctionCmd, error)) (models.Function, error) {
+			cmd, err := define(funcs)
+			if err != nil {
+				return models.Function{}, err
+			}
+			return models.Function{Name: cmd.Name, Params: cmd.Params, Body: cmd.Body, Version: version, CreatedAt: createdAt}, nil
+		}
+	}
+
+	type args struct {
+		req *calculatorv1.CreateFunctionRequest
+	}
+	tests := []struct {
+		name       string
+		setupMocks func(calc *mocks.MockCalculator, repo *mocks.MockFunctionRepository)
+		args       args
+		want       *calculatorv1.CreateFunctionResponse
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "new version of a function",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockFunctionRepository) {
+				repo.EXPECT().CreateFunction(mock.Anything, mock.Anything).RunAndReturn(defineAmong([]models.Function{
+					{Name: "vat", Params: []string{"x"}, Body: "x * 1.2", Version: 1},
+				}, 2))
+				calc.EXPECT().ParseFunction("vat(x) = x * 1.25", map[string]calctypes.Function{
+					"vat": {Name: "vat", Params: []string{"x"}, Body: "x * 1.2", Version: 1},
+				}).Return(calctypes.Function{Name: "vat", Params: []string{"x"}, Body: "x * 1.25"}, nil)
+			},
+			args: args{req: &calculatorv1.CreateFunctionRequest{Definition: "vat(x) = x * 1.25"}},
+			want: &calculatorv1.CreateFunctionResponse{Function: &calculatorv1.Function{
+				Name:      "vat",
+				Params:    []string{"x"},
+				Body:      "x * 1.25",
+				Version:   2,
+				CreatedAt: timestamppb.New(createdAt),
+			}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "malformed definition",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockFunctionRepository) {
+				repo.EXPECT().CreateFunction(mock.Anything, mock.Anything).RunAndReturn(defineAmong(nil, 1))
+				calc.EXPECT().ParseFunction("sqrt(x) = x ^ 0.5", mock.Anything).
+					Return(calctypes.Function{}, fmt.Errorf("%w: \"sqrt\" is a built-in function", calctypes.ErrInvalidFunction))
+			},
+			args:    args{req: &calculatorv1.CreateFunctionRequest{Definition: "sqrt(x) = x ^ 0.5"}},
+			wantErr: errorHasCode(codes.InvalidArgument),
+		},
+		{
+			name: "invalid body with parse error details",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockFunctionRepository) {
+				repo.EXPECT().CreateFunction(mock.Anything, mock.Anything).RunAndReturn(defineAmong(nil, 1))
+				calc.EXPECT().ParseFunction("tax(x) = x * rate", mock.Anything).Return(calctypes.Function{}, &calctypes.ParseError{
+					Offset: 13,
+					Token:  "rate",
+					Reason: calctypes.ReasonUnknownIdentifier,
+				})
+			},
+			args: args{req: &calculatorv1.CreateFunctionRequest{Definition: "tax(x) = x * rate"}},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				st, _ := status.FromError(err)
+				if !assert.Equal(t, codes.InvalidArgument, st.Code(), msgAndArgs...) || !assert.Len(t, st.Details(), 1, msgAndArgs...) {
+					return false
+				}
+				return assert.Equal(t, calculatorv1.ParseErrorReason_PARSE_ERROR_REASON_UNKNOWN_IDENTIFIER,
+					st.Details()[0].(*calculatorv1.ParseError).Reason, msgAndArgs...)
+			},
+		},
+		{
+			name: "redefinition breaking callers",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockFunctionRepository) {
+				repo.EXPECT().CreateFunction(mock.Anything, mock.Anything).RunAndReturn(defineAmong([]models.Function{
+					{Name: "g", Params: []string{"x"}, Body: "x + 1", Version: 1},
+					{Name: "f", Params: []string{"y"}, Body: "g(y) * 2", Version: 1},
+				}, 2))
+				calc.EXPECT().ParseFunction("g(a, b) = a + b", mock.Anything).
+					Return(calctypes.Function{}, fmt.Errorf("%w: in the body of \"f\"", calctypes.ErrFunctionInUse))
+			},
+			args:    args{req: &calculatorv1.CreateFunctionRequest{Definition: "g(a, b) = a + b"}},
+			wantErr: errorHasCode(codes.FailedPrecondition),
+		},
+		{
+			name: "repository error",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockFunctionRepository) {
+				repo.EXPECT().CreateFunction(mock.Anything, mock.Anything).Return(models.Function{}, assert.AnError)
+			},
+			args:    args{req: &calculatorv1.CreateFunctionRequest{Definition: "f() = 1"}},
+			wantErr: errorHasCode(codes.Internal),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			calc := mocks.NewMockCalculator(t)
+			repo := mocks.NewMockFunctionRepository(t)
+
+			tt.setupMocks(calc, repo)
+			svc := NewFunctionService(&config.Config{}, testutil.DiscardLogger(), calc, repo)
+
+			got, err := svc.CreateFunction(ctx, tt.args.req)
+			if !tt.wantErr(t, err, fmt.Sprintf("CreateFunction(%v, %v)", ctx, tt.args.req)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "CreateFunction(%v, %v)", ctx, tt.args.req)
+		})
+	}
+}
+
+func TestFunctionService_ListFunctions(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		setupMocks func(repo *mocks.MockFunctionRepository)
+		want       *calculatorv1.ListFunctionsResponse
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "successful listing",
+			setupMocks: func(repo *mocks.MockFunctionRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return([]models.Function{
+					{Name: "gross", Params: []string{"x", "fee"}, Body: "vat(x) + fee", Version: 1, CreatedAt: createdAt},
+					{Name: "vat", Params: []string{"x"}, Body: "x * 1.2", Version: 3, CreatedAt: createdAt},
+				}, nil)
+			},
+			want: &calculatorv1.ListFunctionsResponse{Functions: []*calculatorv1.Function{
+				{Name: "gross", Params: []string{"x", "fee"}, Body: "vat(x) + fee", Version: 1, CreatedAt: timestamppb.New(createdAt)},
+				{Name: "vat", Params: []string{"x"}, Body: "x * 1.2", Version: 3, CreatedAt: timestamppb.New(createdAt)},
+			}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(repo *mocks.MockFunctionRepository) {
+				repo.EXPECT().ListFunctions(mock.Anything).Return(nil, assert.AnError)
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := mocks.NewMockFunctionRepository(t)
+
+			tt.setupMocks(repo)
+			svc := NewFunctionService(&config.Config{}, testutil.DiscardLogger(), mocks.NewMockCalculator(t), repo)
+
+			got, err := svc.ListFunctions(ctx, &emptypb.Empty{})
+			if !tt.wantErr(t, err, fmt.Sprintf("ListFunctions(%v)", ctx)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "ListFunctions(%v)", ctx)
+		})
+	}
+}
+
+func TestFunctionService_DeleteFunction(t *testing.T) {
+	funcs := []models.Function{
+		{Name: "vat", Params: []string{"x"}, Body: "x * 1.2", Version: 1},
+		{Name: "gross", Params: []string{"x", "fee"}, Body: "vat(x) + fee", Version: 1},
+	}
+	// checkAmong makes the repository check the deletion among the current funcs
+	checkAmong := func(funcs []models.Function) func(context.Context, string, func([]models.Function) error) error {
+		return func(_ context.Context, _ string, check func([]models.Function) error) error {
+			return check(funcs)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		setupMocks func(calc *mocks.MockCalculator, repo *mocks.MockFunctionRepository)
+		req        *calculatorv1.DeleteFunctionRequest
+		wantCode   codes.Code
+	}{
+		{
+			name: "existing function deleted",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockFunctionRepository) {
+				repo.EXPECT().DeleteFunction(mock.Anything, "gross", mock.Anything).RunAndReturn(checkAmong(funcs))
+				calc.EXPECT().CheckFunctionDeletion("gross", mapFunctionsToCalc(funcs)).Return(nil)
+			},
+			req:      &calculatorv1.DeleteFunctionRequest{Name: "gross"},
+			wantCode: codes.OK,
+		},
+		{
+			name: "function called by other functions",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockFunctionRepository) {
+				repo.EXPECT().DeleteFunction(mock.Anything, "vat", mock.Anything).RunAndReturn(checkAmong(funcs))
+				calc.EXPECT().CheckFunctionDeletion("vat", mapFunctionsToCalc(funcs)).
+					Return(fmt.Errorf("%w: in the body of \"gross\"", calctypes.ErrFunctionInUse))
+			},
+			req:      &calculatorv1.DeleteFunctionRequest{Name: "vat"},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "function not found",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockFunctionRepository) {
+				repo.EXPECT().DeleteFunction(mock.Anything, "vat", mock.Anything).Return(models.ErrFunctionNotFound)
+			},
+			req:      &calculatorv1.DeleteFunctionRequest{Name: "vat"},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(calc *mocks.MockCalculator, repo *mocks.MockFunctionRepository) {
+				repo.EXPECT().DeleteFunction(mock.Anything, "vat", mock.Anything).Return(assert.AnError)
+			},
+			req:      &calculatorv1.DeleteFunctionRequest{Name: "vat"},
+			wantCode: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			calc := mocks.NewMockCalculator(t)
+			repo := mocks.NewMockFunctionRepository(t)
+
+			tt.setupMocks(calc, repo)
+			svc := NewFunctionService(&config.Config{}, testutil.DiscardLogger(), calc, repo)
+
+			_, err := svc.DeleteFunction(ctx, tt.req)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
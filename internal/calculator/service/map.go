@@ -1,6 +1,11 @@
 package service
 
 import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	calctypes "github.com/belo4ya/edu-dist-calculate-api/internal/calculator/calc/types"
 	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/repository/models"
 	calculatorv1 "github.com/belo4ya/edu-dist-calculate-api/pkg/calculator/v1"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -9,10 +14,111 @@ import (
 
 func mapExpressionToExpressionResponse(expr models.Expression) *calculatorv1.Expression {
 	return &calculatorv1.Expression{
-		Id:         expr.ID,
-		Expression: expr.Expression,
-		Status:     mapExpressionStatus(expr.Status),
-		Result:     expr.Result,
+		Id:          expr.ID,
+		Expression:  expr.Expression,
+		Status:      mapExpressionStatus(expr.Status),
+		Result:      expr.Result,
+		Variables:   expr.Variables,
+		Arithmetic:  mapArithmetic(expr.Arithmetic),
+		ExactResult: formatExact(expr.ExactResult),
+		Functions:   mapFunctionVersions(expr.Functions),
+	}
+}
+
+func mapFunctionVersions(versions map[string]int) map[string]int32 {
+	if len(versions) == 0 {
+		return nil
+	}
+	res := make(map[string]int32, len(versions))
+	for name, version := range versions {
+		res[name] = int32(version)
+	}
+	return res
+}
+
+func mapFunctionToFunctionResponse(f models.Function) *calculatorv1.Function {
+	return &calculatorv1.Function{
+		Name:      f.Name,
+		Params:    f.Params,
+		Body:      f.Body,
+		Version:   int32(f.Version),
+		CreatedAt: timestamppb.New(f.CreatedAt),
+	}
+}
+
+// mapFunctionsToCalc maps the functions to the ones the calculator expands, by name.
+func mapFunctionsToCalc(funcs []models.Function) map[string]calctypes.Function {
+	res := make(map[string]calctypes.Function, len(funcs))
+	for _, f := range funcs {
+		res[f.Name] = calctypes.Function{Name: f.Name, Params: f.Params, Body: f.Body, Version: f.Version}
+	}
+	return res
+}
+
+// formatExact renders an exact value "a/b" as a decimal if it is finite, e.g. "3/10" as "0.3".
+func formatExact(exact string) string {
+	r, ok := new(big.Rat).SetString(exact)
+	if !ok {
+		return exact
+	}
+
+	// The decimal is finite if the denominator only has the prime factors 2 and 5
+	denom := new(big.Int).Set(r.Denom())
+	twos := denom.TrailingZeroBits()
+	denom.Rsh(denom, twos)
+	fives := uint(0)
+	for five, q, m := big.NewInt(5), new(big.Int), new(big.Int); ; fives++ {
+		if q.QuoRem(denom, five, m); m.Sign() != 0 {
+			break
+		}
+		denom.Set(q)
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return r.RatString()
+	}
+	return r.FloatString(int(max(twos, fives)))
+}
+
+func mapParseError(err *calctypes.ParseError) *calculatorv1.ParseError {
+	return &calculatorv1.ParseError{
+		Offset: int32(err.Offset),
+		Token:  err.Token,
+		Reason: mapParseErrorReason(err.Reason),
+		Detail: err.Detail,
+	}
+}
+
+// mapTokensToRPN renders the tokens as strings: variables by name, function calls as "name/arity".
+func mapTokensToRPN(rpn []calctypes.Token) []string {
+	res := make([]string, 0, len(rpn))
+	for _, t := range rpn {
+		switch {
+		case t.Name != "":
+			res = append(res, t.Name)
+		case t.IsNumber:
+			res = append(res, strconv.FormatFloat(t.Number, 'g', -1, 64))
+		case t.Arity > 0:
+			res = append(res, fmt.Sprintf("%s/%d", t.Symbol, t.Arity))
+		default:
+			res = append(res, t.Symbol)
+		}
+	}
+	return res
+}
+
+func mapTaskToExplainTaskResponse(task models.CreateExpressionTaskCmd) *calculatorv1.ExplainExpressionResponse_Task {
+	return &calculatorv1.ExplainExpressionResponse_Task{
+		Id:              task.ID,
+		ParentTask_1Id:  task.ParentTask1ID,
+		ParentTask_2Id:  task.ParentTask2ID,
+		Arg_1:           task.Arg1,
+		Arg_2:           task.Arg2,
+		Operation:       mapTaskOperation(task.Operation),
+		OperationTime:   durationpb.New(task.OperationTime),
+		ParentTaskIds:   task.ParentTaskIDs,
+		Args:            task.Args,
+		ConditionTaskId: task.ConditionTaskID,
+		ConditionValue:  task.ConditionValue,
 	}
 }
 
@@ -23,24 +129,59 @@ func mapTaskToAgentTaskResponse(task models.Task) *calculatorv1.Task {
 		Arg2:          task.Arg2,
 		Operation:     mapTaskOperation(task.Operation),
 		OperationTime: durationpb.New(task.OperationTime),
+		Args:          task.Args,
+		Arithmetic:    mapArithmetic(task.Arithmetic),
+		ExactArg1:     task.ExactArg1,
+		ExactArg2:     task.ExactArg2,
+		ExactArgs:     task.ExactArgs,
 	}
 }
 
 func mapTaskToInternalTaskResponse(task models.Task) *calculatorv1.ListExpressionTasksResponse_Task {
 	return &calculatorv1.ListExpressionTasksResponse_Task{
-		Id:             task.ID,
-		ExpressionId:   task.ExpressionID,
-		ParentTask_1Id: task.ParentTask1ID,
-		ParentTask_2Id: task.ParentTask2ID,
-		Arg_1:          task.Arg1,
-		Arg_2:          task.Arg2,
-		Operation:      mapTaskOperation(task.Operation),
-		OperationTime:  durationpb.New(task.OperationTime),
-		Status:         mapTaskStatus(task.Status),
-		Result:         task.Result,
-		ExpireAt:       timestamppb.New(task.ExpireAt),
-		CreatedAt:      timestamppb.New(task.CreatedAt),
-		UpdatedAt:      timestamppb.New(task.UpdatedAt),
+		Id:              task.ID,
+		ExpressionId:    task.ExpressionID,
+		ParentTask_1Id:  task.ParentTask1ID,
+		ParentTask_2Id:  task.ParentTask2ID,
+		Arg_1:           task.Arg1,
+		Arg_2:           task.Arg2,
+		Operation:       mapTaskOperation(task.Operation),
+		OperationTime:   durationpb.New(task.OperationTime),
+		Status:          mapTaskStatus(task.Status),
+		Result:          task.Result,
+		ExpireAt:        timestamppb.New(task.ExpireAt),
+		CreatedAt:       timestamppb.New(task.CreatedAt),
+		UpdatedAt:       timestamppb.New(task.UpdatedAt),
+		ParentTaskIds:   task.ParentTaskIDs,
+		Args:            task.Args,
+		Arithmetic:      mapArithmetic(task.Arithmetic),
+		ExactResult:     task.ExactResult,
+		ConditionTaskId: task.ConditionTaskID,
+		ConditionValue:  task.ConditionValue,
+	}
+}
+
+func mapArithmetic(a models.Arithmetic) calculatorv1.Arithmetic {
+	switch a {
+	case models.ArithmeticFloat:
+		return calculatorv1.Arithmetic_ARITHMETIC_FLOAT
+	case models.ArithmeticExact:
+		return calculatorv1.Arithmetic_ARITHMETIC_EXACT
+	case models.ArithmeticBigInt:
+		return calculatorv1.Arithmetic_ARITHMETIC_BIG_INT
+	default:
+		return calculatorv1.Arithmetic_ARITHMETIC_UNSPECIFIED
+	}
+}
+
+func mapArithmeticToModel(a calculatorv1.Arithmetic) models.Arithmetic {
+	switch a {
+	case calculatorv1.Arithmetic_ARITHMETIC_EXACT:
+		return models.ArithmeticExact
+	case calculatorv1.Arithmetic_ARITHMETIC_BIG_INT:
+		return models.ArithmeticBigInt
+	default:
+		return models.ArithmeticFloat
 	}
 }
 
@@ -54,11 +195,38 @@ func mapExpressionStatus(s models.ExpressionStatus) calculatorv1.ExpressionStatu
 		return calculatorv1.ExpressionStatus_EXPRESSION_STATUS_COMPLETED
 	case models.ExpressionStatusFailed:
 		return calculatorv1.ExpressionStatus_EXPRESSION_STATUS_FAILED
+	case models.ExpressionStatusCancelled:
+		return calculatorv1.ExpressionStatus_EXPRESSION_STATUS_CANCELLED
 	default:
 		return calculatorv1.ExpressionStatus_EXPRESSION_STATUS_UNSPECIFIED
 	}
 }
 
+func mapParseErrorReason(r calctypes.ParseErrorReason) calculatorv1.ParseErrorReason {
+	switch r {
+	case calctypes.ReasonEmptyInput:
+		return calculatorv1.ParseErrorReason_PARSE_ERROR_REASON_EMPTY_INPUT
+	case calctypes.ReasonUnknownCharacter:
+		return calculatorv1.ParseErrorReason_PARSE_ERROR_REASON_UNKNOWN_CHARACTER
+	case calctypes.ReasonUnknownIdentifier:
+		return calculatorv1.ParseErrorReason_PARSE_ERROR_REASON_UNKNOWN_IDENTIFIER
+	case calctypes.ReasonInvalidNumber:
+		return calculatorv1.ParseErrorReason_PARSE_ERROR_REASON_INVALID_NUMBER
+	case calctypes.ReasonUnbalancedParen:
+		return calculatorv1.ParseErrorReason_PARSE_ERROR_REASON_UNBALANCED_PAREN
+	case calctypes.ReasonDanglingOperator:
+		return calculatorv1.ParseErrorReason_PARSE_ERROR_REASON_DANGLING_OPERATOR
+	case calctypes.ReasonMissingOperator:
+		return calculatorv1.ParseErrorReason_PARSE_ERROR_REASON_MISSING_OPERATOR
+	case calctypes.ReasonInvalidFunctionCall:
+		return calculatorv1.ParseErrorReason_PARSE_ERROR_REASON_INVALID_FUNCTION_CALL
+	case calctypes.ReasonUnexpectedToken:
+		return calculatorv1.ParseErrorReason_PARSE_ERROR_REASON_UNEXPECTED_TOKEN
+	default:
+		return calculatorv1.ParseErrorReason_PARSE_ERROR_REASON_UNSPECIFIED
+	}
+}
+
 func mapTaskOperation(s models.TaskOperation) calculatorv1.TaskOperation {
 	switch s {
 	case models.TaskOperationAddition:
@@ -69,6 +237,46 @@ func mapTaskOperation(s models.TaskOperation) calculatorv1.TaskOperation {
 		return calculatorv1.TaskOperation_TASK_OPERATION_MULTIPLICATION
 	case models.TaskOperationDivision:
 		return calculatorv1.TaskOperation_TASK_OPERATION_DIVISION
+	case models.TaskOperationNegation:
+		return calculatorv1.TaskOperation_TASK_OPERATION_NEGATION
+	case models.TaskOperationPower:
+		return calculatorv1.TaskOperation_TASK_OPERATION_POWER
+	case models.TaskOperationModulo:
+		return calculatorv1.TaskOperation_TASK_OPERATION_MODULO
+	case models.TaskOperationIntegerDivision:
+		return calculatorv1.TaskOperation_TASK_OPERATION_INTEGER_DIVISION
+	case models.TaskOperationSqrt:
+		return calculatorv1.TaskOperation_TASK_OPERATION_SQRT
+	case models.TaskOperationAbs:
+		return calculatorv1.TaskOperation_TASK_OPERATION_ABS
+	case models.TaskOperationMin:
+		return calculatorv1.TaskOperation_TASK_OPERATION_MIN
+	case models.TaskOperationMax:
+		return calculatorv1.TaskOperation_TASK_OPERATION_MAX
+	case models.TaskOperationRound:
+		return calculatorv1.TaskOperation_TASK_OPERATION_ROUND
+	case models.TaskOperationLog:
+		return calculatorv1.TaskOperation_TASK_OPERATION_LOG
+	case models.TaskOperationLess:
+		return calculatorv1.TaskOperation_TASK_OPERATION_LESS
+	case models.TaskOperationLessOrEqual:
+		return calculatorv1.TaskOperation_TASK_OPERATION_LESS_OR_EQUAL
+	case models.TaskOperationGreater:
+		return calculatorv1.TaskOperation_TASK_OPERATION_GREATER
+	case models.TaskOperationGreaterOrEqual:
+		return calculatorv1.TaskOperation_TASK_OPERATION_GREATER_OR_EQUAL
+	case models.TaskOperationEqual:
+		return calculatorv1.TaskOperation_TASK_OPERATION_EQUAL
+	case models.TaskOperationNotEqual:
+		return calculatorv1.TaskOperation_TASK_OPERATION_NOT_EQUAL
+	case models.TaskOperationAnd:
+		return calculatorv1.TaskOperation_TASK_OPERATION_AND
+	case models.TaskOperationOr:
+		return calculatorv1.TaskOperation_TASK_OPERATION_OR
+	case models.TaskOperationNot:
+		return calculatorv1.TaskOperation_TASK_OPERATION_NOT
+	case models.TaskOperationIf:
+		return calculatorv1.TaskOperation_TASK_OPERATION_IF
 	default:
 		return calculatorv1.TaskOperation_TASK_OPERATION_UNSPECIFIED
 	}
@@ -84,6 +292,10 @@ func mapTaskStatus(s models.TaskStatus) calculatorv1.TaskStatus {
 		return calculatorv1.TaskStatus_TASK_STATUS_COMPLETED
 	case models.TaskStatusFailed:
 		return calculatorv1.TaskStatus_TASK_STATUS_FAILED
+	case models.TaskStatusSkipped:
+		return calculatorv1.TaskStatus_TASK_STATUS_SKIPPED
+	case models.TaskStatusCancelled:
+		return calculatorv1.TaskStatus_TASK_STATUS_CANCELLED
 	default:
 		return calculatorv1.TaskStatus_TASK_STATUS_UNSPECIFIED
 	}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var simplifiedTasksTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "calculator_simplified_tasks_total",
+	Help: "Total number of tasks eliminated by simplification of expressions.",
+})
+
+var reclaimedLeasesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "calculator_reclaimed_task_leases_total",
+	Help: "Total number of expired task leases by outcome: requeued or failed after the maximum number of attempts.",
+}, []string{"outcome"})
+
+func init() {
+	prometheus.MustRegister(simplifiedTasksTotal, reclaimedLeasesTotal)
+}
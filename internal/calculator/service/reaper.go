@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/config"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/repository/models"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/logging"
+)
+
+type LeaseRepository interface {
+	ReclaimExpiredTasks(context.Context, int) (models.ReclaimedTasks, error)
+}
+
+// LeaseReaper periodically returns in-progress tasks with expired leases to the pending queue,
+// so expressions don't hang when agents crash in the middle of tasks.
+type LeaseReaper struct {
+	conf *config.Config
+	log  *slog.Logger
+	repo LeaseRepository
+}
+
+func NewLeaseReaper(conf *config.Config, log *slog.Logger, repo LeaseRepository) *LeaseReaper {
+	return &LeaseReaper{
+		conf: conf,
+		log:  logging.WithName(log, "lease-reaper"),
+		repo: repo,
+	}
+}
+
+// Start reclaims expired leases every TaskReclaimIntervalMs until the context is canceled.
+func (r *LeaseReaper) Start(ctx context.Context) error {
+	ticker := time.NewTicker(time.Duration(r.conf.TaskReclaimIntervalMs) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.reclaim(ctx)
+		}
+	}
+}
+
+func (r *LeaseReaper) reclaim(ctx context.Context) {
+	reclaimed, err := r.repo.ReclaimExpiredTasks(ctx, r.conf.TaskMaxAttempts)
+	if err != nil {
+		r.log.ErrorContext(ctx, "failed to reclaim expired tasks", "error", err)
+		return
+	}
+
+	reclaimedLeasesTotal.WithLabelValues("requeued").Add(float64(reclaimed.Requeued))
+	reclaimedLeasesTotal.WithLabelValues("failed").Add(float64(reclaimed.Failed))
+	if reclaimed.Requeued != 0 || reclaimed.Failed != 0 {
+		r.log.WarnContext(ctx, "reclaimed expired tasks", "requeued", reclaimed.Requeued, "failed", reclaimed.Failed)
+	}
+}
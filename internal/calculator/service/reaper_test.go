@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/config"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/calculator/repository/models"
+	"github.com/belo4ya/edu-dist-calculate-api/internal/testutil"
+	mocks "github.com/belo4ya/edu-dist-calculate-api/internal/testutil/mocks/calculator/service"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLeaseReaper_reclaim(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMocks   func(repo *mocks.MockLeaseRepository)
+		wantRequeued float64
+		wantFailed   float64
+	}{
+		{
+			name: "expired leases reclaimed",
+			setupMocks: func(repo *mocks.MockLeaseRepository) {
+				repo.EXPECT().ReclaimExpiredTasks(mock.Anything, 3).Return(models.ReclaimedTasks{Requeued: 2, Failed: 1}, nil)
+			},
+			wantRequeued: 2,
+			wantFailed:   1,
+		},
+		{
+			name: "no expired leases",
+			setupMocks: func(repo *mocks.MockLeaseRepository) {
+				repo.EXPECT().ReclaimExpiredTasks(mock.Anything, 3).Return(models.ReclaimedTasks{}, nil)
+			},
+		},
+		{
+			name: "repository error",
+			setupMocks: func(repo *mocks.MockLeaseRepository) {
+				repo.EXPECT().ReclaimExpiredTasks(mock.Anything, 3).Return(models.ReclaimedTasks{}, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := mocks.NewMockLeaseRepository(t)
+
+			tt.setupMocks(repo)
+			r := NewLeaseReaper(&config.Config{TaskMaxAttempts: 3}, testutil.DiscardLogger(), repo)
+
+			requeued := promtestutil.ToFloat64(reclaimedLeasesTotal.WithLabelValues("requeued"))
+			failed := promtestutil.ToFloat64(reclaimedLeasesTotal.WithLabelValues("failed"))
+			r.reclaim(ctx)
+			assert.Equal(t, tt.wantRequeued, promtestutil.ToFloat64(reclaimedLeasesTotal.WithLabelValues("requeued"))-requeued)
+			assert.Equal(t, tt.wantFailed, promtestutil.ToFloat64(reclaimedLeasesTotal.WithLabelValues("failed"))-failed)
+		})
+	}
+}
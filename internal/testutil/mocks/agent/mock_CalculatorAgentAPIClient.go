@@ -22,24 +22,71 @@ func (_m *MockCalculatorAgentAPIClient) EXPECT() *MockCalculatorAgentAPIClient_E
 	return &MockCalculatorAgentAPIClient_Expecter{mock: &_m.Mock}
 }
 
+// ExtendTaskLease provides a mock function with given fields: ctx, req
+func (_m *MockCalculatorAgentAPIClient) ExtendTaskLease(ctx context.Context, req *v1.ExtendTaskLeaseRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtendTaskLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.ExtendTaskLeaseRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCalculatorAgentAPIClient_ExtendTaskLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendTaskLease'
+type MockCalculatorAgentAPIClient_ExtendTaskLease_Call struct {
+	*mock.Call
+}
+
+// ExtendTaskLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *v1.ExtendTaskLeaseRequest
+func (_e *MockCalculatorAgentAPIClient_Expecter) ExtendTaskLease(ctx interface{}, req interface{}) *MockCalculatorAgentAPIClient_ExtendTaskLease_Call {
+	return &MockCalculatorAgentAPIClient_ExtendTaskLease_Call{Call: _e.mock.On("ExtendTaskLease", ctx, req)}
+}
+
+func (_c *MockCalculatorAgentAPIClient_ExtendTaskLease_Call) Run(run func(ctx context.Context, req *v1.ExtendTaskLeaseRequest)) *MockCalculatorAgentAPIClient_ExtendTaskLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.ExtendTaskLeaseRequest))
+	})
+	return _c
+}
+
+func (_c *MockCalculatorAgentAPIClient_ExtendTaskLease_Call) Return(_a0 error) *MockCalculatorAgentAPIClient_ExtendTaskLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCalculatorAgentAPIClient_ExtendTaskLease_Call) RunAndReturn(run func(context.Context, *v1.ExtendTaskLeaseRequest) error) *MockCalculatorAgentAPIClient_ExtendTaskLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTask provides a mock function with given fields: ctx
-func (_m *MockCalculatorAgentAPIClient) GetTask(ctx context.Context) (*v1.Task, error) {
+func (_m *MockCalculatorAgentAPIClient) GetTask(ctx context.Context) (*v1.GetTaskResponse, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTask")
 	}
 
-	var r0 *v1.Task
+	var r0 *v1.GetTaskResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*v1.Task, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) (*v1.GetTaskResponse, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *v1.Task); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) *v1.GetTaskResponse); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.Task)
+			r0 = ret.Get(0).(*v1.GetTaskResponse)
 		}
 	}
 
@@ -70,12 +117,12 @@ func (_c *MockCalculatorAgentAPIClient_GetTask_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *MockCalculatorAgentAPIClient_GetTask_Call) Return(_a0 *v1.Task, _a1 error) *MockCalculatorAgentAPIClient_GetTask_Call {
+func (_c *MockCalculatorAgentAPIClient_GetTask_Call) Return(_a0 *v1.GetTaskResponse, _a1 error) *MockCalculatorAgentAPIClient_GetTask_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockCalculatorAgentAPIClient_GetTask_Call) RunAndReturn(run func(context.Context) (*v1.Task, error)) *MockCalculatorAgentAPIClient_GetTask_Call {
+func (_c *MockCalculatorAgentAPIClient_GetTask_Call) RunAndReturn(run func(context.Context) (*v1.GetTaskResponse, error)) *MockCalculatorAgentAPIClient_GetTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
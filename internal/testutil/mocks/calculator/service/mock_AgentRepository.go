@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	models "github.com/belo4ya/edu-dist-calculate-api/internal/calculator/repository/models"
 	mock "github.com/stretchr/testify/mock"
@@ -22,6 +23,65 @@ func (_m *MockAgentRepository) EXPECT() *MockAgentRepository_Expecter {
 	return &MockAgentRepository_Expecter{mock: &_m.Mock}
 }
 
+// ExtendTaskLease provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *MockAgentRepository) ExtendTaskLease(_a0 context.Context, _a1 string, _a2 string, _a3 time.Duration) (time.Time, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtendTaskLease")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (time.Time, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) time.Time); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAgentRepository_ExtendTaskLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendTaskLease'
+type MockAgentRepository_ExtendTaskLease_Call struct {
+	*mock.Call
+}
+
+// ExtendTaskLease is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+//   - _a3 time.Duration
+func (_e *MockAgentRepository_Expecter) ExtendTaskLease(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *MockAgentRepository_ExtendTaskLease_Call {
+	return &MockAgentRepository_ExtendTaskLease_Call{Call: _e.mock.On("ExtendTaskLease", _a0, _a1, _a2, _a3)}
+}
+
+func (_c *MockAgentRepository_ExtendTaskLease_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string, _a3 time.Duration)) *MockAgentRepository_ExtendTaskLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockAgentRepository_ExtendTaskLease_Call) Return(_a0 time.Time, _a1 error) *MockAgentRepository_ExtendTaskLease_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAgentRepository_ExtendTaskLease_Call) RunAndReturn(run func(context.Context, string, string, time.Duration) (time.Time, error)) *MockAgentRepository_ExtendTaskLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FinishTask provides a mock function with given fields: _a0, _a1
 func (_m *MockAgentRepository) FinishTask(_a0 context.Context, _a1 models.FinishTaskCmd) error {
 	ret := _m.Called(_a0, _a1)
@@ -69,9 +129,9 @@ func (_c *MockAgentRepository_FinishTask_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
-// GetPendingTask provides a mock function with given fields: _a0
-func (_m *MockAgentRepository) GetPendingTask(_a0 context.Context) (models.Task, error) {
-	ret := _m.Called(_a0)
+// GetPendingTask provides a mock function with given fields: _a0, _a1
+func (_m *MockAgentRepository) GetPendingTask(_a0 context.Context, _a1 time.Duration) (models.Task, error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPendingTask")
@@ -79,17 +139,17 @@ func (_m *MockAgentRepository) GetPendingTask(_a0 context.Context) (models.Task,
 
 	var r0 models.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (models.Task, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (models.Task, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) models.Task); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) models.Task); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Get(0).(models.Task)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,13 +164,14 @@ type MockAgentRepository_GetPendingTask_Call struct {
 
 // GetPendingTask is a helper method to define mock.On call
 //   - _a0 context.Context
-func (_e *MockAgentRepository_Expecter) GetPendingTask(_a0 interface{}) *MockAgentRepository_GetPendingTask_Call {
-	return &MockAgentRepository_GetPendingTask_Call{Call: _e.mock.On("GetPendingTask", _a0)}
+//   - _a1 time.Duration
+func (_e *MockAgentRepository_Expecter) GetPendingTask(_a0 interface{}, _a1 interface{}) *MockAgentRepository_GetPendingTask_Call {
+	return &MockAgentRepository_GetPendingTask_Call{Call: _e.mock.On("GetPendingTask", _a0, _a1)}
 }
 
-func (_c *MockAgentRepository_GetPendingTask_Call) Run(run func(_a0 context.Context)) *MockAgentRepository_GetPendingTask_Call {
+func (_c *MockAgentRepository_GetPendingTask_Call) Run(run func(_a0 context.Context, _a1 time.Duration)) *MockAgentRepository_GetPendingTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(time.Duration))
 	})
 	return _c
 }
@@ -120,7 +181,7 @@ func (_c *MockAgentRepository_GetPendingTask_Call) Return(_a0 models.Task, _a1 e
 	return _c
 }
 
-func (_c *MockAgentRepository_GetPendingTask_Call) RunAndReturn(run func(context.Context) (models.Task, error)) *MockAgentRepository_GetPendingTask_Call {
+func (_c *MockAgentRepository_GetPendingTask_Call) RunAndReturn(run func(context.Context, time.Duration) (models.Task, error)) *MockAgentRepository_GetPendingTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -21,9 +21,56 @@ func (_m *MockCalculator) EXPECT() *MockCalculator_Expecter {
 	return &MockCalculator_Expecter{mock: &_m.Mock}
 }
 
-// Parse provides a mock function with given fields: _a0
-func (_m *MockCalculator) Parse(_a0 string) ([]types.Token, error) {
-	ret := _m.Called(_a0)
+// CheckFunctionDeletion provides a mock function with given fields: _a0, _a1
+func (_m *MockCalculator) CheckFunctionDeletion(_a0 string, _a1 map[string]types.Function) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckFunctionDeletion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, map[string]types.Function) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCalculator_CheckFunctionDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckFunctionDeletion'
+type MockCalculator_CheckFunctionDeletion_Call struct {
+	*mock.Call
+}
+
+// CheckFunctionDeletion is a helper method to define mock.On call
+//   - _a0 string
+//   - _a1 map[string]types.Function
+func (_e *MockCalculator_Expecter) CheckFunctionDeletion(_a0 interface{}, _a1 interface{}) *MockCalculator_CheckFunctionDeletion_Call {
+	return &MockCalculator_CheckFunctionDeletion_Call{Call: _e.mock.On("CheckFunctionDeletion", _a0, _a1)}
+}
+
+func (_c *MockCalculator_CheckFunctionDeletion_Call) Run(run func(_a0 string, _a1 map[string]types.Function)) *MockCalculator_CheckFunctionDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(map[string]types.Function))
+	})
+	return _c
+}
+
+func (_c *MockCalculator_CheckFunctionDeletion_Call) Return(_a0 error) *MockCalculator_CheckFunctionDeletion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCalculator_CheckFunctionDeletion_Call) RunAndReturn(run func(string, map[string]types.Function) error) *MockCalculator_CheckFunctionDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Parse provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockCalculator) Parse(_a0 string, _a1 map[string]float64, _a2 map[string]types.Function) ([]types.Token, error) {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Parse")
@@ -31,19 +78,19 @@ func (_m *MockCalculator) Parse(_a0 string) ([]types.Token, error) {
 
 	var r0 []types.Token
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]types.Token, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(string, map[string]float64, map[string]types.Function) ([]types.Token, error)); ok {
+		return rf(_a0, _a1, _a2)
 	}
-	if rf, ok := ret.Get(0).(func(string) []types.Token); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(string, map[string]float64, map[string]types.Function) []types.Token); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]types.Token)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(string, map[string]float64, map[string]types.Function) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -58,13 +105,15 @@ type MockCalculator_Parse_Call struct {
 
 // Parse is a helper method to define mock.On call
 //   - _a0 string
-func (_e *MockCalculator_Expecter) Parse(_a0 interface{}) *MockCalculator_Parse_Call {
-	return &MockCalculator_Parse_Call{Call: _e.mock.On("Parse", _a0)}
+//   - _a1 map[string]float64
+//   - _a2 map[string]types.Function
+func (_e *MockCalculator_Expecter) Parse(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockCalculator_Parse_Call {
+	return &MockCalculator_Parse_Call{Call: _e.mock.On("Parse", _a0, _a1, _a2)}
 }
 
-func (_c *MockCalculator_Parse_Call) Run(run func(_a0 string)) *MockCalculator_Parse_Call {
+func (_c *MockCalculator_Parse_Call) Run(run func(_a0 string, _a1 map[string]float64, _a2 map[string]types.Function)) *MockCalculator_Parse_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(map[string]float64), args[2].(map[string]types.Function))
 	})
 	return _c
 }
@@ -74,31 +123,254 @@ func (_c *MockCalculator_Parse_Call) Return(_a0 []types.Token, _a1 error) *MockC
 	return _c
 }
 
-func (_c *MockCalculator_Parse_Call) RunAndReturn(run func(string) ([]types.Token, error)) *MockCalculator_Parse_Call {
+func (_c *MockCalculator_Parse_Call) RunAndReturn(run func(string, map[string]float64, map[string]types.Function) ([]types.Token, error)) *MockCalculator_Parse_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Schedule provides a mock function with given fields: _a0
-func (_m *MockCalculator) Schedule(_a0 []types.Token) []types.Task {
+// ParseExact provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockCalculator) ParseExact(_a0 string, _a1 map[string]float64, _a2 map[string]types.Function) ([]types.Token, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseExact")
+	}
+
+	var r0 []types.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, map[string]float64, map[string]types.Function) ([]types.Token, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, map[string]float64, map[string]types.Function) []types.Token); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, map[string]float64, map[string]types.Function) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCalculator_ParseExact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParseExact'
+type MockCalculator_ParseExact_Call struct {
+	*mock.Call
+}
+
+// ParseExact is a helper method to define mock.On call
+//   - _a0 string
+//   - _a1 map[string]float64
+//   - _a2 map[string]types.Function
+func (_e *MockCalculator_Expecter) ParseExact(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockCalculator_ParseExact_Call {
+	return &MockCalculator_ParseExact_Call{Call: _e.mock.On("ParseExact", _a0, _a1, _a2)}
+}
+
+func (_c *MockCalculator_ParseExact_Call) Run(run func(_a0 string, _a1 map[string]float64, _a2 map[string]types.Function)) *MockCalculator_ParseExact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(map[string]float64), args[2].(map[string]types.Function))
+	})
+	return _c
+}
+
+func (_c *MockCalculator_ParseExact_Call) Return(_a0 []types.Token, _a1 error) *MockCalculator_ParseExact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCalculator_ParseExact_Call) RunAndReturn(run func(string, map[string]float64, map[string]types.Function) ([]types.Token, error)) *MockCalculator_ParseExact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ParseFunction provides a mock function with given fields: _a0, _a1
+func (_m *MockCalculator) ParseFunction(_a0 string, _a1 map[string]types.Function) (types.Function, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseFunction")
+	}
+
+	var r0 types.Function
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, map[string]types.Function) (types.Function, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, map[string]types.Function) types.Function); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(types.Function)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, map[string]types.Function) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCalculator_ParseFunction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParseFunction'
+type MockCalculator_ParseFunction_Call struct {
+	*mock.Call
+}
+
+// ParseFunction is a helper method to define mock.On call
+//   - _a0 string
+//   - _a1 map[string]types.Function
+func (_e *MockCalculator_Expecter) ParseFunction(_a0 interface{}, _a1 interface{}) *MockCalculator_ParseFunction_Call {
+	return &MockCalculator_ParseFunction_Call{Call: _e.mock.On("ParseFunction", _a0, _a1)}
+}
+
+func (_c *MockCalculator_ParseFunction_Call) Run(run func(_a0 string, _a1 map[string]types.Function)) *MockCalculator_ParseFunction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(map[string]types.Function))
+	})
+	return _c
+}
+
+func (_c *MockCalculator_ParseFunction_Call) Return(_a0 types.Function, _a1 error) *MockCalculator_ParseFunction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCalculator_ParseFunction_Call) RunAndReturn(run func(string, map[string]types.Function) (types.Function, error)) *MockCalculator_ParseFunction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ParseInt provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockCalculator) ParseInt(_a0 string, _a1 map[string]float64, _a2 map[string]types.Function) ([]types.Token, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseInt")
+	}
+
+	var r0 []types.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, map[string]float64, map[string]types.Function) ([]types.Token, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, map[string]float64, map[string]types.Function) []types.Token); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, map[string]float64, map[string]types.Function) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCalculator_ParseInt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParseInt'
+type MockCalculator_ParseInt_Call struct {
+	*mock.Call
+}
+
+// ParseInt is a helper method to define mock.On call
+//   - _a0 string
+//   - _a1 map[string]float64
+//   - _a2 map[string]types.Function
+func (_e *MockCalculator_Expecter) ParseInt(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockCalculator_ParseInt_Call {
+	return &MockCalculator_ParseInt_Call{Call: _e.mock.On("ParseInt", _a0, _a1, _a2)}
+}
+
+func (_c *MockCalculator_ParseInt_Call) Run(run func(_a0 string, _a1 map[string]float64, _a2 map[string]types.Function)) *MockCalculator_ParseInt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(map[string]float64), args[2].(map[string]types.Function))
+	})
+	return _c
+}
+
+func (_c *MockCalculator_ParseInt_Call) Return(_a0 []types.Token, _a1 error) *MockCalculator_ParseInt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCalculator_ParseInt_Call) RunAndReturn(run func(string, map[string]float64, map[string]types.Function) ([]types.Token, error)) *MockCalculator_ParseInt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rebalance provides a mock function with given fields: _a0
+func (_m *MockCalculator) Rebalance(_a0 []types.Token) []types.Token {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Schedule")
+		panic("no return value specified for Rebalance")
 	}
 
-	var r0 []types.Task
-	if rf, ok := ret.Get(0).(func([]types.Token) []types.Task); ok {
+	var r0 []types.Token
+	if rf, ok := ret.Get(0).(func([]types.Token) []types.Token); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]types.Task)
+			r0 = ret.Get(0).([]types.Token)
 		}
 	}
 
 	return r0
 }
 
+// MockCalculator_Rebalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rebalance'
+type MockCalculator_Rebalance_Call struct {
+	*mock.Call
+}
+
+// Rebalance is a helper method to define mock.On call
+//   - _a0 []types.Token
+func (_e *MockCalculator_Expecter) Rebalance(_a0 interface{}) *MockCalculator_Rebalance_Call {
+	return &MockCalculator_Rebalance_Call{Call: _e.mock.On("Rebalance", _a0)}
+}
+
+func (_c *MockCalculator_Rebalance_Call) Run(run func(_a0 []types.Token)) *MockCalculator_Rebalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]types.Token))
+	})
+	return _c
+}
+
+func (_c *MockCalculator_Rebalance_Call) Return(_a0 []types.Token) *MockCalculator_Rebalance_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCalculator_Rebalance_Call) RunAndReturn(run func([]types.Token) []types.Token) *MockCalculator_Rebalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Schedule provides a mock function with given fields: _a0
+func (_m *MockCalculator) Schedule(_a0 []types.Token) types.Plan {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Schedule")
+	}
+
+	var r0 types.Plan
+	if rf, ok := ret.Get(0).(func([]types.Token) types.Plan); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(types.Plan)
+	}
+
+	return r0
+}
+
 // MockCalculator_Schedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Schedule'
 type MockCalculator_Schedule_Call struct {
 	*mock.Call
@@ -117,12 +389,120 @@ func (_c *MockCalculator_Schedule_Call) Run(run func(_a0 []types.Token)) *MockCa
 	return _c
 }
 
-func (_c *MockCalculator_Schedule_Call) Return(_a0 []types.Task) *MockCalculator_Schedule_Call {
+func (_c *MockCalculator_Schedule_Call) Return(_a0 types.Plan) *MockCalculator_Schedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCalculator_Schedule_Call) RunAndReturn(run func([]types.Token) types.Plan) *MockCalculator_Schedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Simplify provides a mock function with given fields: _a0
+func (_m *MockCalculator) Simplify(_a0 []types.Token) ([]types.Token, int) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Simplify")
+	}
+
+	var r0 []types.Token
+	var r1 int
+	if rf, ok := ret.Get(0).(func([]types.Token) ([]types.Token, int)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func([]types.Token) []types.Token); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]types.Token) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	return r0, r1
+}
+
+// MockCalculator_Simplify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Simplify'
+type MockCalculator_Simplify_Call struct {
+	*mock.Call
+}
+
+// Simplify is a helper method to define mock.On call
+//   - _a0 []types.Token
+func (_e *MockCalculator_Expecter) Simplify(_a0 interface{}) *MockCalculator_Simplify_Call {
+	return &MockCalculator_Simplify_Call{Call: _e.mock.On("Simplify", _a0)}
+}
+
+func (_c *MockCalculator_Simplify_Call) Run(run func(_a0 []types.Token)) *MockCalculator_Simplify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]types.Token))
+	})
+	return _c
+}
+
+func (_c *MockCalculator_Simplify_Call) Return(_a0 []types.Token, _a1 int) *MockCalculator_Simplify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCalculator_Simplify_Call) RunAndReturn(run func([]types.Token) ([]types.Token, int)) *MockCalculator_Simplify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsedFunctions provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockCalculator) UsedFunctions(_a0 string, _a1 map[string]float64, _a2 map[string]types.Function) map[string]int {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsedFunctions")
+	}
+
+	var r0 map[string]int
+	if rf, ok := ret.Get(0).(func(string, map[string]float64, map[string]types.Function) map[string]int); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	return r0
+}
+
+// MockCalculator_UsedFunctions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsedFunctions'
+type MockCalculator_UsedFunctions_Call struct {
+	*mock.Call
+}
+
+// UsedFunctions is a helper method to define mock.On call
+//   - _a0 string
+//   - _a1 map[string]float64
+//   - _a2 map[string]types.Function
+func (_e *MockCalculator_Expecter) UsedFunctions(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockCalculator_UsedFunctions_Call {
+	return &MockCalculator_UsedFunctions_Call{Call: _e.mock.On("UsedFunctions", _a0, _a1, _a2)}
+}
+
+func (_c *MockCalculator_UsedFunctions_Call) Run(run func(_a0 string, _a1 map[string]float64, _a2 map[string]types.Function)) *MockCalculator_UsedFunctions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(map[string]float64), args[2].(map[string]types.Function))
+	})
+	return _c
+}
+
+func (_c *MockCalculator_UsedFunctions_Call) Return(_a0 map[string]int) *MockCalculator_UsedFunctions_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockCalculator_Schedule_Call) RunAndReturn(run func([]types.Token) []types.Task) *MockCalculator_Schedule_Call {
+func (_c *MockCalculator_UsedFunctions_Call) RunAndReturn(run func(string, map[string]float64, map[string]types.Function) map[string]int) *MockCalculator_UsedFunctions_Call {
 	_c.Call.Return(run)
 	return _c
 }
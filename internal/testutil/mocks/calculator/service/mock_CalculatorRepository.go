@@ -22,6 +22,63 @@ func (_m *MockCalculatorRepository) EXPECT() *MockCalculatorRepository_Expecter
 	return &MockCalculatorRepository_Expecter{mock: &_m.Mock}
 }
 
+// CancelExpression provides a mock function with given fields: _a0, _a1
+func (_m *MockCalculatorRepository) CancelExpression(_a0 context.Context, _a1 string) (models.Expression, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelExpression")
+	}
+
+	var r0 models.Expression
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Expression, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Expression); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(models.Expression)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCalculatorRepository_CancelExpression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelExpression'
+type MockCalculatorRepository_CancelExpression_Call struct {
+	*mock.Call
+}
+
+// CancelExpression is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockCalculatorRepository_Expecter) CancelExpression(_a0 interface{}, _a1 interface{}) *MockCalculatorRepository_CancelExpression_Call {
+	return &MockCalculatorRepository_CancelExpression_Call{Call: _e.mock.On("CancelExpression", _a0, _a1)}
+}
+
+func (_c *MockCalculatorRepository_CancelExpression_Call) Run(run func(_a0 context.Context, _a1 string)) *MockCalculatorRepository_CancelExpression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCalculatorRepository_CancelExpression_Call) Return(_a0 models.Expression, _a1 error) *MockCalculatorRepository_CancelExpression_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCalculatorRepository_CancelExpression_Call) RunAndReturn(run func(context.Context, string) (models.Expression, error)) *MockCalculatorRepository_CancelExpression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateExpression provides a mock function with given fields: _a0, _a1, _a2
 func (_m *MockCalculatorRepository) CreateExpression(_a0 context.Context, _a1 models.CreateExpressionCmd, _a2 []models.CreateExpressionTaskCmd) (string, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -195,6 +252,64 @@ func (_c *MockCalculatorRepository_ListExpressions_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// ListFunctions provides a mock function with given fields: _a0
+func (_m *MockCalculatorRepository) ListFunctions(_a0 context.Context) ([]models.Function, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFunctions")
+	}
+
+	var r0 []models.Function
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Function, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Function); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Function)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCalculatorRepository_ListFunctions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFunctions'
+type MockCalculatorRepository_ListFunctions_Call struct {
+	*mock.Call
+}
+
+// ListFunctions is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockCalculatorRepository_Expecter) ListFunctions(_a0 interface{}) *MockCalculatorRepository_ListFunctions_Call {
+	return &MockCalculatorRepository_ListFunctions_Call{Call: _e.mock.On("ListFunctions", _a0)}
+}
+
+func (_c *MockCalculatorRepository_ListFunctions_Call) Run(run func(_a0 context.Context)) *MockCalculatorRepository_ListFunctions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCalculatorRepository_ListFunctions_Call) Return(_a0 []models.Function, _a1 error) *MockCalculatorRepository_ListFunctions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCalculatorRepository_ListFunctions_Call) RunAndReturn(run func(context.Context) ([]models.Function, error)) *MockCalculatorRepository_ListFunctions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockCalculatorRepository creates a new instance of MockCalculatorRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockCalculatorRepository(t interface {
@@ -12,6 +12,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -37,23 +38,104 @@ const (
 	TaskOperation_TASK_OPERATION_MULTIPLICATION TaskOperation = 3
 	// Division operation (/).
 	TaskOperation_TASK_OPERATION_DIVISION TaskOperation = 4
+	// Negation operation (unary -), uses only the first operand.
+	TaskOperation_TASK_OPERATION_NEGATION TaskOperation = 5
+	// Exponentiation operation (^).
+	TaskOperation_TASK_OPERATION_POWER TaskOperation = 6
+	// Modulo operation (%), the result has the sign of the divisor.
+	TaskOperation_TASK_OPERATION_MODULO TaskOperation = 7
+	// Integer division operation (//), rounds the quotient down.
+	TaskOperation_TASK_OPERATION_INTEGER_DIVISION TaskOperation = 8
+	// Square root function (sqrt(x)).
+	TaskOperation_TASK_OPERATION_SQRT TaskOperation = 9
+	// Absolute value function (abs(x)).
+	TaskOperation_TASK_OPERATION_ABS TaskOperation = 10
+	// Minimum function (min(x, ...)).
+	TaskOperation_TASK_OPERATION_MIN TaskOperation = 11
+	// Maximum function (max(x, ...)).
+	TaskOperation_TASK_OPERATION_MAX TaskOperation = 12
+	// Rounding to the nearest integer, half away from zero (round(x)).
+	TaskOperation_TASK_OPERATION_ROUND TaskOperation = 13
+	// Logarithm function, natural (log(x)) or for the given base (log(x, base)).
+	TaskOperation_TASK_OPERATION_LOG TaskOperation = 14
+	// Comparison (<), results in 1 if true and 0 otherwise, as do other comparisons and logical operations.
+	TaskOperation_TASK_OPERATION_LESS TaskOperation = 15
+	// Comparison (<=).
+	TaskOperation_TASK_OPERATION_LESS_OR_EQUAL TaskOperation = 16
+	// Comparison (>).
+	TaskOperation_TASK_OPERATION_GREATER TaskOperation = 17
+	// Comparison (>=).
+	TaskOperation_TASK_OPERATION_GREATER_OR_EQUAL TaskOperation = 18
+	// Comparison (==).
+	TaskOperation_TASK_OPERATION_EQUAL TaskOperation = 19
+	// Comparison (!=).
+	TaskOperation_TASK_OPERATION_NOT_EQUAL TaskOperation = 20
+	// Logical conjunction (&&), any non-zero operand is true.
+	TaskOperation_TASK_OPERATION_AND TaskOperation = 21
+	// Logical disjunction (||).
+	TaskOperation_TASK_OPERATION_OR TaskOperation = 22
+	// Logical negation (unary !), uses only the first operand.
+	TaskOperation_TASK_OPERATION_NOT TaskOperation = 23
+	// Conditional (if(condition, then, else)), results in args[1] if args[0] is non-zero and args[2] otherwise.
+	// Only the operand of the taken branch is set.
+	TaskOperation_TASK_OPERATION_IF TaskOperation = 24
 )
 
 // Enum value maps for TaskOperation.
 var (
 	TaskOperation_name = map[int32]string{
-		0: "TASK_OPERATION_UNSPECIFIED",
-		1: "TASK_OPERATION_ADDITION",
-		2: "TASK_OPERATION_SUBTRACTION",
-		3: "TASK_OPERATION_MULTIPLICATION",
-		4: "TASK_OPERATION_DIVISION",
+		0:  "TASK_OPERATION_UNSPECIFIED",
+		1:  "TASK_OPERATION_ADDITION",
+		2:  "TASK_OPERATION_SUBTRACTION",
+		3:  "TASK_OPERATION_MULTIPLICATION",
+		4:  "TASK_OPERATION_DIVISION",
+		5:  "TASK_OPERATION_NEGATION",
+		6:  "TASK_OPERATION_POWER",
+		7:  "TASK_OPERATION_MODULO",
+		8:  "TASK_OPERATION_INTEGER_DIVISION",
+		9:  "TASK_OPERATION_SQRT",
+		10: "TASK_OPERATION_ABS",
+		11: "TASK_OPERATION_MIN",
+		12: "TASK_OPERATION_MAX",
+		13: "TASK_OPERATION_ROUND",
+		14: "TASK_OPERATION_LOG",
+		15: "TASK_OPERATION_LESS",
+		16: "TASK_OPERATION_LESS_OR_EQUAL",
+		17: "TASK_OPERATION_GREATER",
+		18: "TASK_OPERATION_GREATER_OR_EQUAL",
+		19: "TASK_OPERATION_EQUAL",
+		20: "TASK_OPERATION_NOT_EQUAL",
+		21: "TASK_OPERATION_AND",
+		22: "TASK_OPERATION_OR",
+		23: "TASK_OPERATION_NOT",
+		24: "TASK_OPERATION_IF",
 	}
 	TaskOperation_value = map[string]int32{
-		"TASK_OPERATION_UNSPECIFIED":    0,
-		"TASK_OPERATION_ADDITION":       1,
-		"TASK_OPERATION_SUBTRACTION":    2,
-		"TASK_OPERATION_MULTIPLICATION": 3,
-		"TASK_OPERATION_DIVISION":       4,
+		"TASK_OPERATION_UNSPECIFIED":      0,
+		"TASK_OPERATION_ADDITION":         1,
+		"TASK_OPERATION_SUBTRACTION":      2,
+		"TASK_OPERATION_MULTIPLICATION":   3,
+		"TASK_OPERATION_DIVISION":         4,
+		"TASK_OPERATION_NEGATION":         5,
+		"TASK_OPERATION_POWER":            6,
+		"TASK_OPERATION_MODULO":           7,
+		"TASK_OPERATION_INTEGER_DIVISION": 8,
+		"TASK_OPERATION_SQRT":             9,
+		"TASK_OPERATION_ABS":              10,
+		"TASK_OPERATION_MIN":              11,
+		"TASK_OPERATION_MAX":              12,
+		"TASK_OPERATION_ROUND":            13,
+		"TASK_OPERATION_LOG":              14,
+		"TASK_OPERATION_LESS":             15,
+		"TASK_OPERATION_LESS_OR_EQUAL":    16,
+		"TASK_OPERATION_GREATER":          17,
+		"TASK_OPERATION_GREATER_OR_EQUAL": 18,
+		"TASK_OPERATION_EQUAL":            19,
+		"TASK_OPERATION_NOT_EQUAL":        20,
+		"TASK_OPERATION_AND":              21,
+		"TASK_OPERATION_OR":               22,
+		"TASK_OPERATION_NOT":              23,
+		"TASK_OPERATION_IF":               24,
 	}
 )
 
@@ -84,6 +166,66 @@ func (TaskOperation) EnumDescriptor() ([]byte, []int) {
 	return file_calculator_v1_agent_proto_rawDescGZIP(), []int{0}
 }
 
+// Number system an expression is calculated in.
+type Arithmetic int32
+
+const (
+	// Not specified, floating-point numbers are used.
+	Arithmetic_ARITHMETIC_UNSPECIFIED Arithmetic = 0
+	// Double-precision floating-point numbers.
+	Arithmetic_ARITHMETIC_FLOAT Arithmetic = 1
+	// Exact rational numbers, encoded as strings: a fraction "a/b" in lowest terms or an integer "a".
+	// sqrt and log aren't supported, powers require an integer exponent. The irrational constants pi and e are rejected
+	// unless variables redefine them, number literals may exceed the float64 range.
+	Arithmetic_ARITHMETIC_EXACT Arithmetic = 2
+	// Arbitrary-precision integers, encoded as decimal strings. Number literals must be integers,
+	// only +, -, *, //, %, ^ with a non-negative exponent, abs, min, max and round are supported.
+	Arithmetic_ARITHMETIC_BIG_INT Arithmetic = 3
+)
+
+// Enum value maps for Arithmetic.
+var (
+	Arithmetic_name = map[int32]string{
+		0: "ARITHMETIC_UNSPECIFIED",
+		1: "ARITHMETIC_FLOAT",
+		2: "ARITHMETIC_EXACT",
+		3: "ARITHMETIC_BIG_INT",
+	}
+	Arithmetic_value = map[string]int32{
+		"ARITHMETIC_UNSPECIFIED": 0,
+		"ARITHMETIC_FLOAT":       1,
+		"ARITHMETIC_EXACT":       2,
+		"ARITHMETIC_BIG_INT":     3,
+	}
+)
+
+func (x Arithmetic) Enum() *Arithmetic {
+	p := new(Arithmetic)
+	*p = x
+	return p
+}
+
+func (x Arithmetic) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Arithmetic) Descriptor() protoreflect.EnumDescriptor {
+	return file_calculator_v1_agent_proto_enumTypes[1].Descriptor()
+}
+
+func (Arithmetic) Type() protoreflect.EnumType {
+	return &file_calculator_v1_agent_proto_enumTypes[1]
+}
+
+func (x Arithmetic) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Arithmetic.Descriptor instead.
+func (Arithmetic) EnumDescriptor() ([]byte, []int) {
+	return file_calculator_v1_agent_proto_rawDescGZIP(), []int{1}
+}
+
 // A single computational task to be processed by an agent.
 type Task struct {
 	state         protoimpl.MessageState
@@ -100,6 +242,16 @@ type Task struct {
 	Operation TaskOperation `protobuf:"varint,4,opt,name=operation,proto3,enum=calculator.v1.TaskOperation" json:"operation,omitempty"`
 	// Expected duration for task processing.
 	OperationTime *durationpb.Duration `protobuf:"bytes,5,opt,name=operation_time,json=operationTime,proto3" json:"operation_time,omitempty"`
+	// Operands of a function operation, arg1 and arg2 aren't used by functions.
+	Args []float64 `protobuf:"fixed64,6,rep,packed,name=args,proto3" json:"args,omitempty"`
+	// Number system to perform the operation in.
+	Arithmetic Arithmetic `protobuf:"varint,7,opt,name=arithmetic,proto3,enum=calculator.v1.Arithmetic" json:"arithmetic,omitempty"`
+	// Exact value of arg1, set for the exact and big integer arithmetic.
+	ExactArg1 string `protobuf:"bytes,8,opt,name=exact_arg1,json=exactArg1,proto3" json:"exact_arg1,omitempty"`
+	// Exact value of arg2, set for the exact and big integer arithmetic.
+	ExactArg2 string `protobuf:"bytes,9,opt,name=exact_arg2,json=exactArg2,proto3" json:"exact_arg2,omitempty"`
+	// Exact values of args, set for the exact and big integer arithmetic.
+	ExactArgs []string `protobuf:"bytes,10,rep,name=exact_args,json=exactArgs,proto3" json:"exact_args,omitempty"`
 }
 
 func (x *Task) Reset() {
@@ -167,6 +319,41 @@ func (x *Task) GetOperationTime() *durationpb.Duration {
 	return nil
 }
 
+func (x *Task) GetArgs() []float64 {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *Task) GetArithmetic() Arithmetic {
+	if x != nil {
+		return x.Arithmetic
+	}
+	return Arithmetic_ARITHMETIC_UNSPECIFIED
+}
+
+func (x *Task) GetExactArg1() string {
+	if x != nil {
+		return x.ExactArg1
+	}
+	return ""
+}
+
+func (x *Task) GetExactArg2() string {
+	if x != nil {
+		return x.ExactArg2
+	}
+	return ""
+}
+
+func (x *Task) GetExactArgs() []string {
+	if x != nil {
+		return x.ExactArgs
+	}
+	return nil
+}
+
 // Contains a task assigned to an agent for processing.
 type GetTaskResponse struct {
 	state         protoimpl.MessageState
@@ -175,6 +362,11 @@ type GetTaskResponse struct {
 
 	// Task to be processed.
 	Task *Task `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Time the task is leased to the agent for, the lease must be extended before it expires.
+	LeaseDuration *durationpb.Duration `protobuf:"bytes,2,opt,name=lease_duration,json=leaseDuration,proto3" json:"lease_duration,omitempty"`
+	// Token of the lease, which the agent must pass to extend the lease and submit the result.
+	// Each time the task is handed to an agent, it gets a new token.
+	LeaseToken string `protobuf:"bytes,3,opt,name=lease_token,json=leaseToken,proto3" json:"lease_token,omitempty"`
 }
 
 func (x *GetTaskResponse) Reset() {
@@ -214,6 +406,20 @@ func (x *GetTaskResponse) GetTask() *Task {
 	return nil
 }
 
+func (x *GetTaskResponse) GetLeaseDuration() *durationpb.Duration {
+	if x != nil {
+		return x.LeaseDuration
+	}
+	return nil
+}
+
+func (x *GetTaskResponse) GetLeaseToken() string {
+	if x != nil {
+		return x.LeaseToken
+	}
+	return ""
+}
+
 // Specifies the task result being submitted.
 type SubmitTaskResultRequest struct {
 	state         protoimpl.MessageState
@@ -222,8 +428,14 @@ type SubmitTaskResultRequest struct {
 
 	// Identifier of the completed task.
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Computation result.
+	// Computation result, NaN if the operation is undefined for the operands (e.g. division by zero).
+	// Infinite results, e.g. of an overflow in "1e300 * 1e300", fail the task as NaN does.
 	Result float64 `protobuf:"fixed64,2,opt,name=result,proto3" json:"result,omitempty"`
+	// Exact computation result for tasks in the exact and big integer arithmetic, empty if the operation is undefined.
+	// The result field holds its approximation.
+	ExactResult string `protobuf:"bytes,3,opt,name=exact_result,json=exactResult,proto3" json:"exact_result,omitempty"`
+	// Token of the lease the task was executed under.
+	LeaseToken string `protobuf:"bytes,4,opt,name=lease_token,json=leaseToken,proto3" json:"lease_token,omitempty"`
 }
 
 func (x *SubmitTaskResultRequest) Reset() {
@@ -270,6 +482,123 @@ func (x *SubmitTaskResultRequest) GetResult() float64 {
 	return 0
 }
 
+func (x *SubmitTaskResultRequest) GetExactResult() string {
+	if x != nil {
+		return x.ExactResult
+	}
+	return ""
+}
+
+func (x *SubmitTaskResultRequest) GetLeaseToken() string {
+	if x != nil {
+		return x.LeaseToken
+	}
+	return ""
+}
+
+// Specifies the task whose lease is extended.
+type ExtendTaskLeaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the task being processed.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Token of the lease to extend.
+	LeaseToken string `protobuf:"bytes,2,opt,name=lease_token,json=leaseToken,proto3" json:"lease_token,omitempty"`
+}
+
+func (x *ExtendTaskLeaseRequest) Reset() {
+	*x = ExtendTaskLeaseRequest{}
+	mi := &file_calculator_v1_agent_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendTaskLeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendTaskLeaseRequest) ProtoMessage() {}
+
+func (x *ExtendTaskLeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_v1_agent_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendTaskLeaseRequest.ProtoReflect.Descriptor instead.
+func (*ExtendTaskLeaseRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_v1_agent_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExtendTaskLeaseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExtendTaskLeaseRequest) GetLeaseToken() string {
+	if x != nil {
+		return x.LeaseToken
+	}
+	return ""
+}
+
+// Contains the extended lease.
+type ExtendTaskLeaseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Time the lease expires at unless it is extended again.
+	ExpireAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+}
+
+func (x *ExtendTaskLeaseResponse) Reset() {
+	*x = ExtendTaskLeaseResponse{}
+	mi := &file_calculator_v1_agent_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendTaskLeaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendTaskLeaseResponse) ProtoMessage() {}
+
+func (x *ExtendTaskLeaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_v1_agent_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendTaskLeaseResponse.ProtoReflect.Descriptor instead.
+func (*ExtendTaskLeaseResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_v1_agent_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExtendTaskLeaseResponse) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
 var File_calculator_v1_agent_proto protoreflect.FileDescriptor
 
 var file_calculator_v1_agent_proto_rawDesc = []byte{
@@ -281,56 +610,138 @@ var file_calculator_v1_agent_proto_rawDesc = []byte{
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
 	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbc, 0x01, 0x0a, 0x04, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
-	0x0a, 0x04, 0x61, 0x72, 0x67, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x61, 0x72,
-	0x67, 0x31, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x32, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x04, 0x61, 0x72, 0x67, 0x32, 0x12, 0x3a, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x63, 0x61, 0x6c, 0x63,
-	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x4f, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
-	0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x54, 0x69, 0x6d, 0x65, 0x22, 0x3a, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
-	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b,
-	0x22, 0x41, 0x0a, 0x17, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x2a, 0xac, 0x01, 0x0a, 0x0d, 0x54, 0x61, 0x73, 0x6b, 0x4f, 0x70, 0x65, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50,
-	0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50,
-	0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x44, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e,
-	0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41,
-	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x55, 0x42, 0x54, 0x52, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e,
-	0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41,
-	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x50, 0x4c, 0x49, 0x43, 0x41, 0x54,
-	0x49, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50,
-	0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x49, 0x56, 0x49, 0x53, 0x49, 0x4f, 0x4e,
-	0x10, 0x04, 0x32, 0xd8, 0x01, 0x0a, 0x0c, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x59, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1e, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
-	0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e,
-	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x6d,
-	0x0a, 0x10, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x12, 0x26, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x3a, 0x01, 0x2a, 0x22, 0x0e, 0x2f,
-	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x61, 0x73, 0x6b, 0x42, 0x40, 0x5a,
-	0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x65, 0x6c, 0x6f,
-	0x34, 0x79, 0x61, 0x2f, 0x65, 0x64, 0x75, 0x2d, 0x64, 0x69, 0x73, 0x74, 0x2d, 0x63, 0x61, 0x6c,
-	0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x63,
-	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe8, 0x02, 0x0a, 0x04, 0x54, 0x61, 0x73, 0x6b, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x61,
+	0x72, 0x67, 0x31, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x32, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x04, 0x61, 0x72, 0x67, 0x32, 0x12, 0x3a, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x63, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x01, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x61, 0x72, 0x69,
+	0x74, 0x68, 0x6d, 0x65, 0x74, 0x69, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e,
+	0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72,
+	0x69, 0x74, 0x68, 0x6d, 0x65, 0x74, 0x69, 0x63, 0x52, 0x0a, 0x61, 0x72, 0x69, 0x74, 0x68, 0x6d,
+	0x65, 0x74, 0x69, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x61, 0x63, 0x74, 0x5f, 0x61, 0x72,
+	0x67, 0x31, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x61, 0x63, 0x74, 0x41,
+	0x72, 0x67, 0x31, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x61, 0x63, 0x74, 0x5f, 0x61, 0x72, 0x67,
+	0x32, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x61, 0x63, 0x74, 0x41, 0x72,
+	0x67, 0x32, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x61, 0x63, 0x74, 0x5f, 0x61, 0x72, 0x67, 0x73,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x61, 0x63, 0x74, 0x41, 0x72, 0x67,
+	0x73, 0x22, 0x9d, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x40,
+	0x0a, 0x0e, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0d, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x85, 0x01, 0x0a, 0x17, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x61, 0x73, 0x6b,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x61, 0x63, 0x74, 0x5f, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x61,
+	0x63, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x49, 0x0a, 0x16, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x52, 0x0a, 0x17, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x54, 0x61,
+	0x73, 0x6b, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x37, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x74, 0x2a, 0xc8, 0x05, 0x0a, 0x0d, 0x54, 0x61, 0x73,
+	0x6b, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x54, 0x41,
+	0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41,
+	0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x44, 0x44,
+	0x49, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x54, 0x41, 0x53, 0x4b, 0x5f,
+	0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x55, 0x42, 0x54, 0x52, 0x41,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x54, 0x41, 0x53, 0x4b, 0x5f,
+	0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x50,
+	0x4c, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41,
+	0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x49, 0x56,
+	0x49, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41, 0x53, 0x4b, 0x5f,
+	0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x45, 0x47, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x10, 0x05, 0x12, 0x18, 0x0a, 0x14, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45,
+	0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x10, 0x06, 0x12, 0x19,
+	0x0a, 0x15, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x4d, 0x4f, 0x44, 0x55, 0x4c, 0x4f, 0x10, 0x07, 0x12, 0x23, 0x0a, 0x1f, 0x54, 0x41, 0x53,
+	0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x54, 0x45,
+	0x47, 0x45, 0x52, 0x5f, 0x44, 0x49, 0x56, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x08, 0x12, 0x17,
+	0x0a, 0x13, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x53, 0x51, 0x52, 0x54, 0x10, 0x09, 0x12, 0x16, 0x0a, 0x12, 0x54, 0x41, 0x53, 0x4b, 0x5f,
+	0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x42, 0x53, 0x10, 0x0a, 0x12,
+	0x16, 0x0a, 0x12, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x4d, 0x49, 0x4e, 0x10, 0x0b, 0x12, 0x16, 0x0a, 0x12, 0x54, 0x41, 0x53, 0x4b, 0x5f,
+	0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x41, 0x58, 0x10, 0x0c, 0x12,
+	0x18, 0x0a, 0x14, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x0d, 0x12, 0x16, 0x0a, 0x12, 0x54, 0x41, 0x53,
+	0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x4f, 0x47, 0x10,
+	0x0e, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x45, 0x53, 0x53, 0x10, 0x0f, 0x12, 0x20, 0x0a, 0x1c, 0x54, 0x41,
+	0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x45, 0x53,
+	0x53, 0x5f, 0x4f, 0x52, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x10, 0x12, 0x1a, 0x0a, 0x16,
+	0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x47,
+	0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x10, 0x11, 0x12, 0x23, 0x0a, 0x1f, 0x54, 0x41, 0x53, 0x4b,
+	0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x47, 0x52, 0x45, 0x41, 0x54,
+	0x45, 0x52, 0x5f, 0x4f, 0x52, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x12, 0x12, 0x18, 0x0a,
+	0x14, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x13, 0x12, 0x1c, 0x0a, 0x18, 0x54, 0x41, 0x53, 0x4b, 0x5f,
+	0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x51,
+	0x55, 0x41, 0x4c, 0x10, 0x14, 0x12, 0x16, 0x0a, 0x12, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50,
+	0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x4e, 0x44, 0x10, 0x15, 0x12, 0x15, 0x0a,
+	0x11, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x4f, 0x52, 0x10, 0x16, 0x12, 0x16, 0x0a, 0x12, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45,
+	0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x54, 0x10, 0x17, 0x12, 0x15, 0x0a, 0x11,
+	0x54, 0x41, 0x53, 0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x49,
+	0x46, 0x10, 0x18, 0x2a, 0x6c, 0x0a, 0x0a, 0x41, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x65, 0x74, 0x69,
+	0x63, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x45, 0x54, 0x49, 0x43, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a,
+	0x10, 0x41, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x45, 0x54, 0x49, 0x43, 0x5f, 0x46, 0x4c, 0x4f, 0x41,
+	0x54, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x45, 0x54, 0x49,
+	0x43, 0x5f, 0x45, 0x58, 0x41, 0x43, 0x54, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x52, 0x49,
+	0x54, 0x48, 0x4d, 0x45, 0x54, 0x49, 0x43, 0x5f, 0x42, 0x49, 0x47, 0x5f, 0x49, 0x4e, 0x54, 0x10,
+	0x03, 0x32, 0xdc, 0x02, 0x0a, 0x0c, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x59, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1e, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x6d, 0x0a,
+	0x10, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x26, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x3a, 0x01, 0x2a, 0x22, 0x0e, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x81, 0x01, 0x0a,
+	0x0f, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x65, 0x61, 0x73, 0x65,
+	0x12, 0x25, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x65, 0x61, 0x73, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x54, 0x61,
+	0x73, 0x6b, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x3a, 0x01, 0x2a, 0x22, 0x14, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x61, 0x73, 0x6b, 0x2f, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x42, 0x40, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62,
+	0x65, 0x6c, 0x6f, 0x34, 0x79, 0x61, 0x2f, 0x65, 0x64, 0x75, 0x2d, 0x64, 0x69, 0x73, 0x74, 0x2d,
+	0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x76, 0x31, 0x3b,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -345,29 +756,38 @@ func file_calculator_v1_agent_proto_rawDescGZIP() []byte {
 	return file_calculator_v1_agent_proto_rawDescData
 }
 
-var file_calculator_v1_agent_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_calculator_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_calculator_v1_agent_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_calculator_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_calculator_v1_agent_proto_goTypes = []any{
 	(TaskOperation)(0),              // 0: calculator.v1.TaskOperation
-	(*Task)(nil),                    // 1: calculator.v1.Task
-	(*GetTaskResponse)(nil),         // 2: calculator.v1.GetTaskResponse
-	(*SubmitTaskResultRequest)(nil), // 3: calculator.v1.SubmitTaskResultRequest
-	(*durationpb.Duration)(nil),     // 4: google.protobuf.Duration
-	(*emptypb.Empty)(nil),           // 5: google.protobuf.Empty
+	(Arithmetic)(0),                 // 1: calculator.v1.Arithmetic
+	(*Task)(nil),                    // 2: calculator.v1.Task
+	(*GetTaskResponse)(nil),         // 3: calculator.v1.GetTaskResponse
+	(*SubmitTaskResultRequest)(nil), // 4: calculator.v1.SubmitTaskResultRequest
+	(*ExtendTaskLeaseRequest)(nil),  // 5: calculator.v1.ExtendTaskLeaseRequest
+	(*ExtendTaskLeaseResponse)(nil), // 6: calculator.v1.ExtendTaskLeaseResponse
+	(*durationpb.Duration)(nil),     // 7: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),   // 8: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),           // 9: google.protobuf.Empty
 }
 var file_calculator_v1_agent_proto_depIdxs = []int32{
 	0, // 0: calculator.v1.Task.operation:type_name -> calculator.v1.TaskOperation
-	4, // 1: calculator.v1.Task.operation_time:type_name -> google.protobuf.Duration
-	1, // 2: calculator.v1.GetTaskResponse.task:type_name -> calculator.v1.Task
-	5, // 3: calculator.v1.AgentService.GetTask:input_type -> google.protobuf.Empty
-	3, // 4: calculator.v1.AgentService.SubmitTaskResult:input_type -> calculator.v1.SubmitTaskResultRequest
-	2, // 5: calculator.v1.AgentService.GetTask:output_type -> calculator.v1.GetTaskResponse
-	5, // 6: calculator.v1.AgentService.SubmitTaskResult:output_type -> google.protobuf.Empty
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	7, // 1: calculator.v1.Task.operation_time:type_name -> google.protobuf.Duration
+	1, // 2: calculator.v1.Task.arithmetic:type_name -> calculator.v1.Arithmetic
+	2, // 3: calculator.v1.GetTaskResponse.task:type_name -> calculator.v1.Task
+	7, // 4: calculator.v1.GetTaskResponse.lease_duration:type_name -> google.protobuf.Duration
+	8, // 5: calculator.v1.ExtendTaskLeaseResponse.expire_at:type_name -> google.protobuf.Timestamp
+	9, // 6: calculator.v1.AgentService.GetTask:input_type -> google.protobuf.Empty
+	4, // 7: calculator.v1.AgentService.SubmitTaskResult:input_type -> calculator.v1.SubmitTaskResultRequest
+	5, // 8: calculator.v1.AgentService.ExtendTaskLease:input_type -> calculator.v1.ExtendTaskLeaseRequest
+	3, // 9: calculator.v1.AgentService.GetTask:output_type -> calculator.v1.GetTaskResponse
+	9, // 10: calculator.v1.AgentService.SubmitTaskResult:output_type -> google.protobuf.Empty
+	6, // 11: calculator.v1.AgentService.ExtendTaskLease:output_type -> calculator.v1.ExtendTaskLeaseResponse
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_calculator_v1_agent_proto_init() }
@@ -380,8 +800,8 @@ func file_calculator_v1_agent_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_calculator_v1_agent_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      2,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
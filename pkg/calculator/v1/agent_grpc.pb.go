@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	AgentService_GetTask_FullMethodName          = "/calculator.v1.AgentService/GetTask"
 	AgentService_SubmitTaskResult_FullMethodName = "/calculator.v1.AgentService/SubmitTaskResult"
+	AgentService_ExtendTaskLease_FullMethodName  = "/calculator.v1.AgentService/ExtendTaskLease"
 )
 
 // AgentServiceClient is the client API for AgentService service.
@@ -33,7 +34,12 @@ type AgentServiceClient interface {
 	// Get task for execution (for agents).
 	GetTask(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetTaskResponse, error)
 	// Submit task processing result (from agents).
+	// Fails with FAILED_PRECONDITION if the task isn't in progress or the lease token isn't the current one,
+	// e.g. the lease expired and the task was handed to another agent.
 	SubmitTaskResult(ctx context.Context, in *SubmitTaskResultRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Extend the lease of the task being processed (from agents).
+	// Agents must call it periodically, otherwise the task is handed to another agent once its lease expires.
+	ExtendTaskLease(ctx context.Context, in *ExtendTaskLeaseRequest, opts ...grpc.CallOption) (*ExtendTaskLeaseResponse, error)
 }
 
 type agentServiceClient struct {
@@ -64,6 +70,16 @@ func (c *agentServiceClient) SubmitTaskResult(ctx context.Context, in *SubmitTas
 	return out, nil
 }
 
+func (c *agentServiceClient) ExtendTaskLease(ctx context.Context, in *ExtendTaskLeaseRequest, opts ...grpc.CallOption) (*ExtendTaskLeaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExtendTaskLeaseResponse)
+	err := c.cc.Invoke(ctx, AgentService_ExtendTaskLease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServiceServer is the server API for AgentService service.
 // All implementations should embed UnimplementedAgentServiceServer
 // for forward compatibility.
@@ -73,7 +89,12 @@ type AgentServiceServer interface {
 	// Get task for execution (for agents).
 	GetTask(context.Context, *emptypb.Empty) (*GetTaskResponse, error)
 	// Submit task processing result (from agents).
+	// Fails with FAILED_PRECONDITION if the task isn't in progress or the lease token isn't the current one,
+	// e.g. the lease expired and the task was handed to another agent.
 	SubmitTaskResult(context.Context, *SubmitTaskResultRequest) (*emptypb.Empty, error)
+	// Extend the lease of the task being processed (from agents).
+	// Agents must call it periodically, otherwise the task is handed to another agent once its lease expires.
+	ExtendTaskLease(context.Context, *ExtendTaskLeaseRequest) (*ExtendTaskLeaseResponse, error)
 }
 
 // UnimplementedAgentServiceServer should be embedded to have
@@ -89,6 +110,9 @@ func (UnimplementedAgentServiceServer) GetTask(context.Context, *emptypb.Empty)
 func (UnimplementedAgentServiceServer) SubmitTaskResult(context.Context, *SubmitTaskResultRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitTaskResult not implemented")
 }
+func (UnimplementedAgentServiceServer) ExtendTaskLease(context.Context, *ExtendTaskLeaseRequest) (*ExtendTaskLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendTaskLease not implemented")
+}
 func (UnimplementedAgentServiceServer) testEmbeddedByValue() {}
 
 // UnsafeAgentServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -145,6 +169,24 @@ func _AgentService_SubmitTaskResult_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentService_ExtendTaskLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendTaskLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).ExtendTaskLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_ExtendTaskLease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).ExtendTaskLease(ctx, req.(*ExtendTaskLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AgentService_ServiceDesc is the grpc.ServiceDesc for AgentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -160,6 +202,10 @@ var AgentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitTaskResult",
 			Handler:    _AgentService_SubmitTaskResult_Handler,
 		},
+		{
+			MethodName: "ExtendTaskLease",
+			Handler:    _AgentService_ExtendTaskLease_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "calculator/v1/agent.proto",
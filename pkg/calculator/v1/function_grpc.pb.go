@@ -0,0 +1,212 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: calculator/v1/function.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FunctionService_CreateFunction_FullMethodName = "/calculator.v1.FunctionService/CreateFunction"
+	FunctionService_ListFunctions_FullMethodName  = "/calculator.v1.FunctionService/ListFunctions"
+	FunctionService_DeleteFunction_FullMethodName = "/calculator.v1.FunctionService/DeleteFunction"
+)
+
+// FunctionServiceClient is the client API for FunctionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Manages user-defined functions, which expressions can call like the built-in ones.
+type FunctionServiceClient interface {
+	// Defines a function, or replaces the function with the same name by its new version.
+	// Expressions submitted earlier keep the version they were calculated with.
+	// Fails with FAILED_PRECONDITION if the new version breaks the functions calling it, e.g. changes the number of parameters.
+	CreateFunction(ctx context.Context, in *CreateFunctionRequest, opts ...grpc.CallOption) (*CreateFunctionResponse, error)
+	// Returns the current versions of all functions.
+	ListFunctions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListFunctionsResponse, error)
+	// Deletes a function, so new expressions can't call it.
+	// Fails with FAILED_PRECONDITION if other functions call it.
+	DeleteFunction(ctx context.Context, in *DeleteFunctionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type functionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFunctionServiceClient(cc grpc.ClientConnInterface) FunctionServiceClient {
+	return &functionServiceClient{cc}
+}
+
+func (c *functionServiceClient) CreateFunction(ctx context.Context, in *CreateFunctionRequest, opts ...grpc.CallOption) (*CreateFunctionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFunctionResponse)
+	err := c.cc.Invoke(ctx, FunctionService_CreateFunction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *functionServiceClient) ListFunctions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListFunctionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFunctionsResponse)
+	err := c.cc.Invoke(ctx, FunctionService_ListFunctions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *functionServiceClient) DeleteFunction(ctx context.Context, in *DeleteFunctionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FunctionService_DeleteFunction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FunctionServiceServer is the server API for FunctionService service.
+// All implementations should embed UnimplementedFunctionServiceServer
+// for forward compatibility.
+//
+// Manages user-defined functions, which expressions can call like the built-in ones.
+type FunctionServiceServer interface {
+	// Defines a function, or replaces the function with the same name by its new version.
+	// Expressions submitted earlier keep the version they were calculated with.
+	// Fails with FAILED_PRECONDITION if the new version breaks the functions calling it, e.g. changes the number of parameters.
+	CreateFunction(context.Context, *CreateFunctionRequest) (*CreateFunctionResponse, error)
+	// Returns the current versions of all functions.
+	ListFunctions(context.Context, *emptypb.Empty) (*ListFunctionsResponse, error)
+	// Deletes a function, so new expressions can't call it.
+	// Fails with FAILED_PRECONDITION if other functions call it.
+	DeleteFunction(context.Context, *DeleteFunctionRequest) (*emptypb.Empty, error)
+}
+
+// UnimplementedFunctionServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFunctionServiceServer struct{}
+
+func (UnimplementedFunctionServiceServer) CreateFunction(context.Context, *CreateFunctionRequest) (*CreateFunctionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFunction not implemented")
+}
+func (UnimplementedFunctionServiceServer) ListFunctions(context.Context, *emptypb.Empty) (*ListFunctionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFunctions not implemented")
+}
+func (UnimplementedFunctionServiceServer) DeleteFunction(context.Context, *DeleteFunctionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFunction not implemented")
+}
+func (UnimplementedFunctionServiceServer) testEmbeddedByValue() {}
+
+// UnsafeFunctionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FunctionServiceServer will
+// result in compilation errors.
+type UnsafeFunctionServiceServer interface {
+	mustEmbedUnimplementedFunctionServiceServer()
+}
+
+func RegisterFunctionServiceServer(s grpc.ServiceRegistrar, srv FunctionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFunctionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FunctionService_ServiceDesc, srv)
+}
+
+func _FunctionService_CreateFunction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFunctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FunctionServiceServer).CreateFunction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FunctionService_CreateFunction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FunctionServiceServer).CreateFunction(ctx, req.(*CreateFunctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FunctionService_ListFunctions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FunctionServiceServer).ListFunctions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FunctionService_ListFunctions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FunctionServiceServer).ListFunctions(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FunctionService_DeleteFunction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFunctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FunctionServiceServer).DeleteFunction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FunctionService_DeleteFunction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FunctionServiceServer).DeleteFunction(ctx, req.(*DeleteFunctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FunctionService_ServiceDesc is the grpc.ServiceDesc for FunctionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FunctionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "calculator.v1.FunctionService",
+	HandlerType: (*FunctionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateFunction",
+			Handler:    _FunctionService_CreateFunction_Handler,
+		},
+		{
+			MethodName: "ListFunctions",
+			Handler:    _FunctionService_ListFunctions_Handler,
+		},
+		{
+			MethodName: "DeleteFunction",
+			Handler:    _FunctionService_DeleteFunction_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "calculator/v1/function.proto",
+}
@@ -11,6 +11,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
@@ -37,6 +38,8 @@ const (
 	ExpressionStatus_EXPRESSION_STATUS_COMPLETED ExpressionStatus = 3
 	// Expression calculation failed.
 	ExpressionStatus_EXPRESSION_STATUS_FAILED ExpressionStatus = 4
+	// Expression calculation was cancelled by the user.
+	ExpressionStatus_EXPRESSION_STATUS_CANCELLED ExpressionStatus = 5
 )
 
 // Enum value maps for ExpressionStatus.
@@ -47,6 +50,7 @@ var (
 		2: "EXPRESSION_STATUS_IN_PROGRESS",
 		3: "EXPRESSION_STATUS_COMPLETED",
 		4: "EXPRESSION_STATUS_FAILED",
+		5: "EXPRESSION_STATUS_CANCELLED",
 	}
 	ExpressionStatus_value = map[string]int32{
 		"EXPRESSION_STATUS_UNSPECIFIED": 0,
@@ -54,6 +58,7 @@ var (
 		"EXPRESSION_STATUS_IN_PROGRESS": 2,
 		"EXPRESSION_STATUS_COMPLETED":   3,
 		"EXPRESSION_STATUS_FAILED":      4,
+		"EXPRESSION_STATUS_CANCELLED":   5,
 	}
 )
 
@@ -84,6 +89,161 @@ func (ExpressionStatus) EnumDescriptor() ([]byte, []int) {
 	return file_calculator_v1_public_proto_rawDescGZIP(), []int{0}
 }
 
+// Reason why an expression can't be parsed.
+type ParseErrorReason int32
+
+const (
+	// Reason not specified.
+	ParseErrorReason_PARSE_ERROR_REASON_UNSPECIFIED ParseErrorReason = 0
+	// Expression is empty or consists of whitespaces only.
+	ParseErrorReason_PARSE_ERROR_REASON_EMPTY_INPUT ParseErrorReason = 1
+	// Character isn't allowed in expressions.
+	ParseErrorReason_PARSE_ERROR_REASON_UNKNOWN_CHARACTER ParseErrorReason = 2
+	// Identifier is neither a function nor a constant nor a passed variable.
+	ParseErrorReason_PARSE_ERROR_REASON_UNKNOWN_IDENTIFIER ParseErrorReason = 3
+	// Number literal is malformed.
+	ParseErrorReason_PARSE_ERROR_REASON_INVALID_NUMBER ParseErrorReason = 4
+	// Parenthesis or square bracket has no matching pair or is closed by a bracket of another kind.
+	ParseErrorReason_PARSE_ERROR_REASON_UNBALANCED_PAREN ParseErrorReason = 5
+	// Operator misses an operand.
+	ParseErrorReason_PARSE_ERROR_REASON_DANGLING_OPERATOR ParseErrorReason = 6
+	// Two operands follow each other without an operator.
+	ParseErrorReason_PARSE_ERROR_REASON_MISSING_OPERATOR ParseErrorReason = 7
+	// Function is called without parentheses, with an empty or a wrong number of arguments.
+	ParseErrorReason_PARSE_ERROR_REASON_INVALID_FUNCTION_CALL ParseErrorReason = 8
+	// Token isn't allowed at its position, e.g. a comma outside of a function call.
+	ParseErrorReason_PARSE_ERROR_REASON_UNEXPECTED_TOKEN ParseErrorReason = 9
+)
+
+// Enum value maps for ParseErrorReason.
+var (
+	ParseErrorReason_name = map[int32]string{
+		0: "PARSE_ERROR_REASON_UNSPECIFIED",
+		1: "PARSE_ERROR_REASON_EMPTY_INPUT",
+		2: "PARSE_ERROR_REASON_UNKNOWN_CHARACTER",
+		3: "PARSE_ERROR_REASON_UNKNOWN_IDENTIFIER",
+		4: "PARSE_ERROR_REASON_INVALID_NUMBER",
+		5: "PARSE_ERROR_REASON_UNBALANCED_PAREN",
+		6: "PARSE_ERROR_REASON_DANGLING_OPERATOR",
+		7: "PARSE_ERROR_REASON_MISSING_OPERATOR",
+		8: "PARSE_ERROR_REASON_INVALID_FUNCTION_CALL",
+		9: "PARSE_ERROR_REASON_UNEXPECTED_TOKEN",
+	}
+	ParseErrorReason_value = map[string]int32{
+		"PARSE_ERROR_REASON_UNSPECIFIED":           0,
+		"PARSE_ERROR_REASON_EMPTY_INPUT":           1,
+		"PARSE_ERROR_REASON_UNKNOWN_CHARACTER":     2,
+		"PARSE_ERROR_REASON_UNKNOWN_IDENTIFIER":    3,
+		"PARSE_ERROR_REASON_INVALID_NUMBER":        4,
+		"PARSE_ERROR_REASON_UNBALANCED_PAREN":      5,
+		"PARSE_ERROR_REASON_DANGLING_OPERATOR":     6,
+		"PARSE_ERROR_REASON_MISSING_OPERATOR":      7,
+		"PARSE_ERROR_REASON_INVALID_FUNCTION_CALL": 8,
+		"PARSE_ERROR_REASON_UNEXPECTED_TOKEN":      9,
+	}
+)
+
+func (x ParseErrorReason) Enum() *ParseErrorReason {
+	p := new(ParseErrorReason)
+	*p = x
+	return p
+}
+
+func (x ParseErrorReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ParseErrorReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_calculator_v1_public_proto_enumTypes[1].Descriptor()
+}
+
+func (ParseErrorReason) Type() protoreflect.EnumType {
+	return &file_calculator_v1_public_proto_enumTypes[1]
+}
+
+func (x ParseErrorReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ParseErrorReason.Descriptor instead.
+func (ParseErrorReason) EnumDescriptor() ([]byte, []int) {
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{1}
+}
+
+// Describes why an expression is invalid, returned in the status details of a failed Calculate.
+type ParseError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Byte offset of the offending token in the expression.
+	Offset int32 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Offending token, empty for an empty expression.
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// Why the expression is invalid.
+	Reason ParseErrorReason `protobuf:"varint,3,opt,name=reason,proto3,enum=calculator.v1.ParseErrorReason" json:"reason,omitempty"`
+	// Optional clarification of the reason, e.g. "multiple decimal points".
+	Detail string `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *ParseError) Reset() {
+	*x = ParseError{}
+	mi := &file_calculator_v1_public_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseError) ProtoMessage() {}
+
+func (x *ParseError) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_v1_public_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseError.ProtoReflect.Descriptor instead.
+func (*ParseError) Descriptor() ([]byte, []int) {
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ParseError) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ParseError) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ParseError) GetReason() ParseErrorReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ParseErrorReason_PARSE_ERROR_REASON_UNSPECIFIED
+}
+
+func (x *ParseError) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
 // Request for submitting a new expression.
 type CalculateRequest struct {
 	state         protoimpl.MessageState
@@ -92,11 +252,26 @@ type CalculateRequest struct {
 
 	// Arithmetic expression to calculate.
 	Expression string `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	// Values of variables used in the expression, e.g. {"rate": 0.2} for "100 * rate".
+	// They take precedence over the predefined constants "pi" and "e".
+	// Names must be identifiers other than built-in functions, values must be finite, otherwise the request is rejected.
+	Variables map[string]float64 `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// Whether to reassociate chains of "+", "-" and "*" to calculate more tasks in parallel.
+	// It may change floating-point rounding of the result. Defaults to the server configuration.
+	Rebalance *bool `protobuf:"varint,3,opt,name=rebalance,proto3,oneof" json:"rebalance,omitempty"`
+	// Whether to calculate operations over literals in place and drop identities like "x*1" and "x+0",
+	// so fewer tasks are sent to agents. Defaults to the server configuration.
+	// Ignored for the exact and big integer arithmetic.
+	Simplify *bool `protobuf:"varint,4,opt,name=simplify,proto3,oneof" json:"simplify,omitempty"`
+	// Number system to calculate the expression in, floating-point numbers by default.
+	// Variables are converted to the exact arithmetic by their shortest decimal representation, e.g. 0.1 is 1/10.
+	// The big integer arithmetic rejects fractional literals and variables, e.g. 0.5 or pi.
+	Arithmetic Arithmetic `protobuf:"varint,5,opt,name=arithmetic,proto3,enum=calculator.v1.Arithmetic" json:"arithmetic,omitempty"`
 }
 
 func (x *CalculateRequest) Reset() {
 	*x = CalculateRequest{}
-	mi := &file_calculator_v1_public_proto_msgTypes[0]
+	mi := &file_calculator_v1_public_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -108,7 +283,7 @@ func (x *CalculateRequest) String() string {
 func (*CalculateRequest) ProtoMessage() {}
 
 func (x *CalculateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_calculator_v1_public_proto_msgTypes[0]
+	mi := &file_calculator_v1_public_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -121,7 +296,7 @@ func (x *CalculateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CalculateRequest.ProtoReflect.Descriptor instead.
 func (*CalculateRequest) Descriptor() ([]byte, []int) {
-	return file_calculator_v1_public_proto_rawDescGZIP(), []int{0}
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *CalculateRequest) GetExpression() string {
@@ -131,6 +306,34 @@ func (x *CalculateRequest) GetExpression() string {
 	return ""
 }
 
+func (x *CalculateRequest) GetVariables() map[string]float64 {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *CalculateRequest) GetRebalance() bool {
+	if x != nil && x.Rebalance != nil {
+		return *x.Rebalance
+	}
+	return false
+}
+
+func (x *CalculateRequest) GetSimplify() bool {
+	if x != nil && x.Simplify != nil {
+		return *x.Simplify
+	}
+	return false
+}
+
+func (x *CalculateRequest) GetArithmetic() Arithmetic {
+	if x != nil {
+		return x.Arithmetic
+	}
+	return Arithmetic_ARITHMETIC_UNSPECIFIED
+}
+
 // Response after expression submission.
 type CalculateResponse struct {
 	state         protoimpl.MessageState
@@ -139,11 +342,13 @@ type CalculateResponse struct {
 
 	// Unique identifier of the submitted expression.
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Number of tasks eliminated by simplification.
+	TasksSaved int32 `protobuf:"varint,2,opt,name=tasks_saved,json=tasksSaved,proto3" json:"tasks_saved,omitempty"`
 }
 
 func (x *CalculateResponse) Reset() {
 	*x = CalculateResponse{}
-	mi := &file_calculator_v1_public_proto_msgTypes[1]
+	mi := &file_calculator_v1_public_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -155,7 +360,7 @@ func (x *CalculateResponse) String() string {
 func (*CalculateResponse) ProtoMessage() {}
 
 func (x *CalculateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_calculator_v1_public_proto_msgTypes[1]
+	mi := &file_calculator_v1_public_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,7 +373,7 @@ func (x *CalculateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CalculateResponse.ProtoReflect.Descriptor instead.
 func (*CalculateResponse) Descriptor() ([]byte, []int) {
-	return file_calculator_v1_public_proto_rawDescGZIP(), []int{1}
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CalculateResponse) GetId() string {
@@ -178,6 +383,13 @@ func (x *CalculateResponse) GetId() string {
 	return ""
 }
 
+func (x *CalculateResponse) GetTasksSaved() int32 {
+	if x != nil {
+		return x.TasksSaved
+	}
+	return 0
+}
+
 // Information about an arithmetic expression.
 type Expression struct {
 	state         protoimpl.MessageState
@@ -192,11 +404,21 @@ type Expression struct {
 	Status ExpressionStatus `protobuf:"varint,3,opt,name=status,proto3,enum=calculator.v1.ExpressionStatus" json:"status,omitempty"`
 	// Calculation result (if completed).
 	Result float64 `protobuf:"fixed64,4,opt,name=result,proto3" json:"result,omitempty"`
+	// Values of constants and variables the expression was calculated with.
+	Variables map[string]float64 `protobuf:"bytes,5,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// Number system the expression is calculated in.
+	Arithmetic Arithmetic `protobuf:"varint,6,opt,name=arithmetic,proto3,enum=calculator.v1.Arithmetic" json:"arithmetic,omitempty"`
+	// Exact calculation result for the exact arithmetic (if completed): a decimal, e.g. "0.3",
+	// or a fraction "a/b" if the decimal is infinite; a decimal integer for the big integer arithmetic.
+	// The result field holds its approximation.
+	ExactResult string `protobuf:"bytes,7,opt,name=exact_result,json=exactResult,proto3" json:"exact_result,omitempty"`
+	// Versions of the user-defined functions the expression called, directly or through other functions.
+	Functions map[string]int32 `protobuf:"bytes,8,rep,name=functions,proto3" json:"functions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 }
 
 func (x *Expression) Reset() {
 	*x = Expression{}
-	mi := &file_calculator_v1_public_proto_msgTypes[2]
+	mi := &file_calculator_v1_public_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -208,7 +430,7 @@ func (x *Expression) String() string {
 func (*Expression) ProtoMessage() {}
 
 func (x *Expression) ProtoReflect() protoreflect.Message {
-	mi := &file_calculator_v1_public_proto_msgTypes[2]
+	mi := &file_calculator_v1_public_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -221,7 +443,7 @@ func (x *Expression) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Expression.ProtoReflect.Descriptor instead.
 func (*Expression) Descriptor() ([]byte, []int) {
-	return file_calculator_v1_public_proto_rawDescGZIP(), []int{2}
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Expression) GetId() string {
@@ -252,6 +474,34 @@ func (x *Expression) GetResult() float64 {
 	return 0
 }
 
+func (x *Expression) GetVariables() map[string]float64 {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *Expression) GetArithmetic() Arithmetic {
+	if x != nil {
+		return x.Arithmetic
+	}
+	return Arithmetic_ARITHMETIC_UNSPECIFIED
+}
+
+func (x *Expression) GetExactResult() string {
+	if x != nil {
+		return x.ExactResult
+	}
+	return ""
+}
+
+func (x *Expression) GetFunctions() map[string]int32 {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
 // Contains a list of all expressions.
 type ListExpressionsResponse struct {
 	state         protoimpl.MessageState
@@ -264,7 +514,7 @@ type ListExpressionsResponse struct {
 
 func (x *ListExpressionsResponse) Reset() {
 	*x = ListExpressionsResponse{}
-	mi := &file_calculator_v1_public_proto_msgTypes[3]
+	mi := &file_calculator_v1_public_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -276,7 +526,7 @@ func (x *ListExpressionsResponse) String() string {
 func (*ListExpressionsResponse) ProtoMessage() {}
 
 func (x *ListExpressionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_calculator_v1_public_proto_msgTypes[3]
+	mi := &file_calculator_v1_public_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -289,7 +539,7 @@ func (x *ListExpressionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListExpressionsResponse.ProtoReflect.Descriptor instead.
 func (*ListExpressionsResponse) Descriptor() ([]byte, []int) {
-	return file_calculator_v1_public_proto_rawDescGZIP(), []int{3}
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ListExpressionsResponse) GetExpressions() []*Expression {
@@ -311,7 +561,7 @@ type GetExpressionRequest struct {
 
 func (x *GetExpressionRequest) Reset() {
 	*x = GetExpressionRequest{}
-	mi := &file_calculator_v1_public_proto_msgTypes[4]
+	mi := &file_calculator_v1_public_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -323,7 +573,7 @@ func (x *GetExpressionRequest) String() string {
 func (*GetExpressionRequest) ProtoMessage() {}
 
 func (x *GetExpressionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_calculator_v1_public_proto_msgTypes[4]
+	mi := &file_calculator_v1_public_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -336,7 +586,7 @@ func (x *GetExpressionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetExpressionRequest.ProtoReflect.Descriptor instead.
 func (*GetExpressionRequest) Descriptor() ([]byte, []int) {
-	return file_calculator_v1_public_proto_rawDescGZIP(), []int{4}
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetExpressionRequest) GetId() string {
@@ -358,7 +608,7 @@ type GetExpressionResponse struct {
 
 func (x *GetExpressionResponse) Reset() {
 	*x = GetExpressionResponse{}
-	mi := &file_calculator_v1_public_proto_msgTypes[5]
+	mi := &file_calculator_v1_public_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -370,7 +620,7 @@ func (x *GetExpressionResponse) String() string {
 func (*GetExpressionResponse) ProtoMessage() {}
 
 func (x *GetExpressionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_calculator_v1_public_proto_msgTypes[5]
+	mi := &file_calculator_v1_public_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -383,7 +633,7 @@ func (x *GetExpressionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetExpressionResponse.ProtoReflect.Descriptor instead.
 func (*GetExpressionResponse) Descriptor() ([]byte, []int) {
-	return file_calculator_v1_public_proto_rawDescGZIP(), []int{5}
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetExpressionResponse) GetExpression() *Expression {
@@ -393,91 +643,685 @@ func (x *GetExpressionResponse) GetExpression() *Expression {
 	return nil
 }
 
+// Request to cancel an expression.
+type CancelExpressionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the expression to cancel.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CancelExpressionRequest) Reset() {
+	*x = CancelExpressionRequest{}
+	mi := &file_calculator_v1_public_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelExpressionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelExpressionRequest) ProtoMessage() {}
+
+func (x *CancelExpressionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_v1_public_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelExpressionRequest.ProtoReflect.Descriptor instead.
+func (*CancelExpressionRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelExpressionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Response containing the cancelled expression.
+type CancelExpressionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The cancelled expression.
+	Expression *Expression `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+}
+
+func (x *CancelExpressionResponse) Reset() {
+	*x = CancelExpressionResponse{}
+	mi := &file_calculator_v1_public_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelExpressionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelExpressionResponse) ProtoMessage() {}
+
+func (x *CancelExpressionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_v1_public_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelExpressionResponse.ProtoReflect.Descriptor instead.
+func (*CancelExpressionResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelExpressionResponse) GetExpression() *Expression {
+	if x != nil {
+		return x.Expression
+	}
+	return nil
+}
+
+// Request to explain an expression.
+type ExplainExpressionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Arithmetic expression to explain.
+	Expression string `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	// Values of variables used in the expression.
+	Variables map[string]float64 `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// Whether to reassociate chains of "+", "-" and "*" to calculate more tasks in parallel.
+	// Defaults to the server configuration.
+	Rebalance *bool `protobuf:"varint,3,opt,name=rebalance,proto3,oneof" json:"rebalance,omitempty"`
+	// Whether to calculate operations over literals in place and drop identities like "x*1" and "x+0".
+	// Defaults to the server configuration. Ignored for the exact and big integer arithmetic.
+	Simplify *bool `protobuf:"varint,4,opt,name=simplify,proto3,oneof" json:"simplify,omitempty"`
+	// Number system to explain the expression in, as in CalculateRequest.
+	Arithmetic Arithmetic `protobuf:"varint,5,opt,name=arithmetic,proto3,enum=calculator.v1.Arithmetic" json:"arithmetic,omitempty"`
+}
+
+func (x *ExplainExpressionRequest) Reset() {
+	*x = ExplainExpressionRequest{}
+	mi := &file_calculator_v1_public_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainExpressionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainExpressionRequest) ProtoMessage() {}
+
+func (x *ExplainExpressionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_v1_public_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainExpressionRequest.ProtoReflect.Descriptor instead.
+func (*ExplainExpressionRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExplainExpressionRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *ExplainExpressionRequest) GetVariables() map[string]float64 {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *ExplainExpressionRequest) GetRebalance() bool {
+	if x != nil && x.Rebalance != nil {
+		return *x.Rebalance
+	}
+	return false
+}
+
+func (x *ExplainExpressionRequest) GetSimplify() bool {
+	if x != nil && x.Simplify != nil {
+		return *x.Simplify
+	}
+	return false
+}
+
+func (x *ExplainExpressionRequest) GetArithmetic() Arithmetic {
+	if x != nil {
+		return x.Arithmetic
+	}
+	return Arithmetic_ARITHMETIC_UNSPECIFIED
+}
+
+// Execution plan of an expression.
+type ExplainExpressionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Expression in Reverse Polish Notation, e.g. ["1", "2", "3", "*", "+"] for "1+2*3".
+	// Variables are shown by name, function calls as "name/arity".
+	Rpn []string `protobuf:"bytes,1,rep,name=rpn,proto3" json:"rpn,omitempty"`
+	// Tasks in the order they are scheduled, every task follows its parents.
+	Tasks []*ExplainExpressionResponse_Task `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// Number of tasks in the longest chain of dependent tasks.
+	CriticalPathLength int32 `protobuf:"varint,3,opt,name=critical_path_length,json=criticalPathLength,proto3" json:"critical_path_length,omitempty"`
+	// Estimated calculation time if all independent tasks are calculated in parallel.
+	// Both branches of conditions are taken into account, so it is an upper bound.
+	EstimatedTime *durationpb.Duration `protobuf:"bytes,4,opt,name=estimated_time,json=estimatedTime,proto3" json:"estimated_time,omitempty"`
+	// Value of the expression if it requires no tasks.
+	Value float64 `protobuf:"fixed64,5,opt,name=value,proto3" json:"value,omitempty"`
+	// Number of tasks eliminated by simplification.
+	TasksSaved int32 `protobuf:"varint,6,opt,name=tasks_saved,json=tasksSaved,proto3" json:"tasks_saved,omitempty"`
+}
+
+func (x *ExplainExpressionResponse) Reset() {
+	*x = ExplainExpressionResponse{}
+	mi := &file_calculator_v1_public_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainExpressionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainExpressionResponse) ProtoMessage() {}
+
+func (x *ExplainExpressionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_v1_public_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainExpressionResponse.ProtoReflect.Descriptor instead.
+func (*ExplainExpressionResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExplainExpressionResponse) GetRpn() []string {
+	if x != nil {
+		return x.Rpn
+	}
+	return nil
+}
+
+func (x *ExplainExpressionResponse) GetTasks() []*ExplainExpressionResponse_Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ExplainExpressionResponse) GetCriticalPathLength() int32 {
+	if x != nil {
+		return x.CriticalPathLength
+	}
+	return 0
+}
+
+func (x *ExplainExpressionResponse) GetEstimatedTime() *durationpb.Duration {
+	if x != nil {
+		return x.EstimatedTime
+	}
+	return nil
+}
+
+func (x *ExplainExpressionResponse) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ExplainExpressionResponse) GetTasksSaved() int32 {
+	if x != nil {
+		return x.TasksSaved
+	}
+	return 0
+}
+
+// Task the expression would be split into.
+type ExplainExpressionResponse_Task struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the task, valid only within the response.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Identifier of the first parent task.
+	ParentTask_1Id string `protobuf:"bytes,2,opt,name=parent_task_1_id,json=parentTask1Id,proto3" json:"parent_task_1_id,omitempty"`
+	// Identifier of the second parent task.
+	ParentTask_2Id string `protobuf:"bytes,3,opt,name=parent_task_2_id,json=parentTask2Id,proto3" json:"parent_task_2_id,omitempty"`
+	// First operand value.
+	Arg_1 float64 `protobuf:"fixed64,4,opt,name=arg_1,json=arg1,proto3" json:"arg_1,omitempty"`
+	// Second operand value.
+	Arg_2 float64 `protobuf:"fixed64,5,opt,name=arg_2,json=arg2,proto3" json:"arg_2,omitempty"`
+	// Mathematical operation to perform.
+	Operation TaskOperation `protobuf:"varint,6,opt,name=operation,proto3,enum=calculator.v1.TaskOperation" json:"operation,omitempty"`
+	// Expected duration for task processing.
+	OperationTime *durationpb.Duration `protobuf:"bytes,7,opt,name=operation_time,json=operationTime,proto3" json:"operation_time,omitempty"`
+	// Identifiers of the parent tasks of a function operation ("" for literal operands).
+	ParentTaskIds []string `protobuf:"bytes,8,rep,name=parent_task_ids,json=parentTaskIds,proto3" json:"parent_task_ids,omitempty"`
+	// Operands of a function operation.
+	Args []float64 `protobuf:"fixed64,9,rep,packed,name=args,proto3" json:"args,omitempty"`
+	// Identifier of the condition task deciding whether the task is executed, empty for unconditional tasks.
+	ConditionTaskId string `protobuf:"bytes,10,opt,name=condition_task_id,json=conditionTaskId,proto3" json:"condition_task_id,omitempty"`
+	// Result of the condition (true for non-zero) the task is executed on.
+	ConditionValue bool `protobuf:"varint,11,opt,name=condition_value,json=conditionValue,proto3" json:"condition_value,omitempty"`
+}
+
+func (x *ExplainExpressionResponse_Task) Reset() {
+	*x = ExplainExpressionResponse_Task{}
+	mi := &file_calculator_v1_public_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainExpressionResponse_Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainExpressionResponse_Task) ProtoMessage() {}
+
+func (x *ExplainExpressionResponse_Task) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_v1_public_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainExpressionResponse_Task.ProtoReflect.Descriptor instead.
+func (*ExplainExpressionResponse_Task) Descriptor() ([]byte, []int) {
+	return file_calculator_v1_public_proto_rawDescGZIP(), []int{10, 0}
+}
+
+func (x *ExplainExpressionResponse_Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExplainExpressionResponse_Task) GetParentTask_1Id() string {
+	if x != nil {
+		return x.ParentTask_1Id
+	}
+	return ""
+}
+
+func (x *ExplainExpressionResponse_Task) GetParentTask_2Id() string {
+	if x != nil {
+		return x.ParentTask_2Id
+	}
+	return ""
+}
+
+func (x *ExplainExpressionResponse_Task) GetArg_1() float64 {
+	if x != nil {
+		return x.Arg_1
+	}
+	return 0
+}
+
+func (x *ExplainExpressionResponse_Task) GetArg_2() float64 {
+	if x != nil {
+		return x.Arg_2
+	}
+	return 0
+}
+
+func (x *ExplainExpressionResponse_Task) GetOperation() TaskOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return TaskOperation_TASK_OPERATION_UNSPECIFIED
+}
+
+func (x *ExplainExpressionResponse_Task) GetOperationTime() *durationpb.Duration {
+	if x != nil {
+		return x.OperationTime
+	}
+	return nil
+}
+
+func (x *ExplainExpressionResponse_Task) GetParentTaskIds() []string {
+	if x != nil {
+		return x.ParentTaskIds
+	}
+	return nil
+}
+
+func (x *ExplainExpressionResponse_Task) GetArgs() []float64 {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *ExplainExpressionResponse_Task) GetConditionTaskId() string {
+	if x != nil {
+		return x.ConditionTaskId
+	}
+	return ""
+}
+
+func (x *ExplainExpressionResponse_Task) GetConditionValue() bool {
+	if x != nil {
+		return x.ConditionValue
+	}
+	return false
+}
+
 var File_calculator_v1_public_proto protoreflect.FileDescriptor
 
 var file_calculator_v1_public_proto_rawDesc = []byte{
 	0x0a, 0x1a, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x76, 0x31, 0x2f,
 	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x63, 0x61,
-	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67,
-	0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x32, 0x2f, 0x6f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x32, 0x0a, 0x10, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78,
-	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x23, 0x0a, 0x11, 0x43, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x19, 0x63, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70,
+	0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x32, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f,
+	0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x8b, 0x01, 0x0a, 0x0a, 0x50, 0x61, 0x72, 0x73, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x37,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f,
+	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x72, 0x73, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22,
+	0xd8, 0x02, 0x0a, 0x10, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4c, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x73, 0x12, 0x21, 0x0a, 0x09, 0x72, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x66,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x08, 0x73, 0x69, 0x6d, 0x70, 0x6c,
+	0x69, 0x66, 0x79, 0x88, 0x01, 0x01, 0x12, 0x39, 0x0a, 0x0a, 0x61, 0x72, 0x69, 0x74, 0x68, 0x6d,
+	0x65, 0x74, 0x69, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72, 0x69, 0x74, 0x68,
+	0x6d, 0x65, 0x74, 0x69, 0x63, 0x52, 0x0a, 0x61, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x65, 0x74, 0x69,
+	0x63, 0x1a, 0x3c, 0x0a, 0x0e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42,
+	0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x42, 0x0b, 0x0a,
+	0x09, 0x5f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x66, 0x79, 0x22, 0x44, 0x0a, 0x11, 0x43, 0x61,
 	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
-	0x8d, 0x01, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1e,
-	0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x37,
-	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f,
-	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45,
-	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22,
-	0x56, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x78,
-	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e,
-	0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x72,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x26, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x45, 0x78,
-	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
-	0x52, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63,
-	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x2a, 0xb6, 0x01, 0x0a, 0x10, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x1d, 0x45, 0x58, 0x50, 0x52,
-	0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e,
-	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x45,
-	0x58, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
-	0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x45, 0x58,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x5f, 0x73, 0x61, 0x76, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x53, 0x61, 0x76, 0x65, 0x64,
+	0x22, 0xf7, 0x03, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x37, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1f, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x46, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x56,
+	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x76,
+	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x61, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x65, 0x74, 0x69, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72, 0x69,
+	0x74, 0x68, 0x6d, 0x65, 0x74, 0x69, 0x63, 0x52, 0x0a, 0x61, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x65,
+	0x74, 0x69, 0x63, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x61, 0x63, 0x74, 0x5f, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x61, 0x63, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x46, 0x0a, 0x09, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x09, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x3c,
+	0x0a, 0x0e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3c, 0x0a, 0x0e,
+	0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x56, 0x0a, 0x17, 0x4c, 0x69,
+	0x73, 0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x26, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x52, 0x0a, 0x15, 0x47, 0x65,
+	0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x29,
+	0x0a, 0x17, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x55, 0x0a, 0x18, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0xe8, 0x02, 0x0a, 0x18, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x45, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x54, 0x0a,
+	0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x36, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62,
+	0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62,
+	0x6c, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x09, 0x72, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x62, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x69,
+	0x66, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x08, 0x73, 0x69, 0x6d, 0x70,
+	0x6c, 0x69, 0x66, 0x79, 0x88, 0x01, 0x01, 0x12, 0x39, 0x0a, 0x0a, 0x61, 0x72, 0x69, 0x74, 0x68,
+	0x6d, 0x65, 0x74, 0x69, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x65, 0x74, 0x69, 0x63, 0x52, 0x0a, 0x61, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x65, 0x74,
+	0x69, 0x63, 0x1a, 0x3c, 0x0a, 0x0e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x42, 0x0b,
+	0x0a, 0x09, 0x5f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x66, 0x79, 0x22, 0xc1, 0x05, 0x0a, 0x19,
+	0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x70, 0x6e,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x72, 0x70, 0x6e, 0x12, 0x43, 0x0a, 0x05, 0x74,
+	0x61, 0x73, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61,
+	0x69, 0x6e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73,
+	0x12, 0x30, 0x0a, 0x14, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x70, 0x61, 0x74,
+	0x68, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12,
+	0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x50, 0x61, 0x74, 0x68, 0x4c, 0x65, 0x6e, 0x67,
+	0x74, 0x68, 0x12, 0x40, 0x0a, 0x0e, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61,
+	0x73, 0x6b, 0x73, 0x5f, 0x73, 0x61, 0x76, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x53, 0x61, 0x76, 0x65, 0x64, 0x1a, 0xa1, 0x03, 0x0a, 0x04,
+	0x54, 0x61, 0x73, 0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x10, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x74,
+	0x61, 0x73, 0x6b, 0x5f, 0x31, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x31, 0x49, 0x64, 0x12, 0x27, 0x0a,
+	0x10, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x32, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x54,
+	0x61, 0x73, 0x6b, 0x32, 0x49, 0x64, 0x12, 0x13, 0x0a, 0x05, 0x61, 0x72, 0x67, 0x5f, 0x31, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x61, 0x72, 0x67, 0x31, 0x12, 0x13, 0x0a, 0x05, 0x61,
+	0x72, 0x67, 0x5f, 0x32, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x61, 0x72, 0x67, 0x32,
+	0x12, 0x3a, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0e,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x26,
+	0x0a, 0x0f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x54,
+	0x61, 0x73, 0x6b, 0x49, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x09,
+	0x20, 0x03, 0x28, 0x01, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2a,
+	0xd7, 0x01, 0x0a, 0x10, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x1d, 0x45, 0x58, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49,
+	0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x45, 0x58, 0x50, 0x52, 0x45,
+	0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e,
+	0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x45, 0x58, 0x50, 0x52, 0x45, 0x53,
+	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x49, 0x4e, 0x5f, 0x50,
+	0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x45, 0x58, 0x50,
+	0x52, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43,
+	0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x58,
 	0x50, 0x52, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
-	0x49, 0x4e, 0x5f, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x1f, 0x0a,
-	0x1b, 0x45, 0x58, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54,
-	0x55, 0x53, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x1c,
-	0x0a, 0x18, 0x45, 0x58, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41,
-	0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x32, 0xc7, 0x03, 0x0a,
-	0x11, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0xc3, 0x01, 0x0a, 0x09, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65,
-	0x12, 0x1f, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x20, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x73, 0x92, 0x41, 0x54, 0x4a, 0x52, 0x0a, 0x03, 0x32, 0x30, 0x31, 0x12,
-	0x4b, 0x0a, 0x23, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x20, 0x61, 0x63,
-	0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x20, 0x66, 0x6f, 0x72, 0x20, 0x63, 0x61, 0x6c, 0x63, 0x75,
-	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x22, 0x1a, 0x20, 0x2e, 0x63, 0x61, 0x6c,
+	0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1f, 0x0a, 0x1b, 0x45, 0x58, 0x50, 0x52,
+	0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x41,
+	0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x2a, 0xa9, 0x03, 0x0a, 0x10, 0x50, 0x61,
+	0x72, 0x73, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x22,
+	0x0a, 0x1e, 0x50, 0x41, 0x52, 0x53, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x52, 0x45,
+	0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e, 0x50, 0x41, 0x52, 0x53, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x45, 0x4d, 0x50, 0x54, 0x59, 0x5f, 0x49,
+	0x4e, 0x50, 0x55, 0x54, 0x10, 0x01, 0x12, 0x28, 0x0a, 0x24, 0x50, 0x41, 0x52, 0x53, 0x45, 0x5f,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x4b,
+	0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x43, 0x48, 0x41, 0x52, 0x41, 0x43, 0x54, 0x45, 0x52, 0x10, 0x02,
+	0x12, 0x29, 0x0a, 0x25, 0x50, 0x41, 0x52, 0x53, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f,
+	0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x49,
+	0x44, 0x45, 0x4e, 0x54, 0x49, 0x46, 0x49, 0x45, 0x52, 0x10, 0x03, 0x12, 0x25, 0x0a, 0x21, 0x50,
+	0x41, 0x52, 0x53, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f,
+	0x4e, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52,
+	0x10, 0x04, 0x12, 0x27, 0x0a, 0x23, 0x50, 0x41, 0x52, 0x53, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x42, 0x41, 0x4c, 0x41, 0x4e,
+	0x43, 0x45, 0x44, 0x5f, 0x50, 0x41, 0x52, 0x45, 0x4e, 0x10, 0x05, 0x12, 0x28, 0x0a, 0x24, 0x50,
+	0x41, 0x52, 0x53, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f,
+	0x4e, 0x5f, 0x44, 0x41, 0x4e, 0x47, 0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41,
+	0x54, 0x4f, 0x52, 0x10, 0x06, 0x12, 0x27, 0x0a, 0x23, 0x50, 0x41, 0x52, 0x53, 0x45, 0x5f, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4d, 0x49, 0x53, 0x53,
+	0x49, 0x4e, 0x47, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x10, 0x07, 0x12, 0x2c,
+	0x0a, 0x28, 0x50, 0x41, 0x52, 0x53, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x52, 0x45,
+	0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x46, 0x55, 0x4e,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x41, 0x4c, 0x4c, 0x10, 0x08, 0x12, 0x27, 0x0a, 0x23,
+	0x50, 0x41, 0x52, 0x53, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x52, 0x45, 0x41, 0x53,
+	0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x45, 0x58, 0x50, 0x45, 0x43, 0x54, 0x45, 0x44, 0x5f, 0x54, 0x4f,
+	0x4b, 0x45, 0x4e, 0x10, 0x09, 0x32, 0xbf, 0x06, 0x0a, 0x11, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xa4, 0x02, 0x0a, 0x09,
+	0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x61, 0x6c,
 	0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75,
-	0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x16, 0x3a, 0x01, 0x2a, 0x22, 0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x63,
-	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x6e, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74,
-	0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x26, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
-	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x7c, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x45,
-	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x63, 0x61, 0x6c, 0x63,
-	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24,
-	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47,
-	0x65, 0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x12, 0x18, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x42, 0x40, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x65, 0x6c, 0x6f, 0x34, 0x79, 0x61, 0x2f, 0x65, 0x64, 0x75,
-	0x2d, 0x64, 0x69, 0x73, 0x74, 0x2d, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x2d,
-	0x61, 0x70, 0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
-	0x6f, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xd3, 0x01, 0x92,
+	0x41, 0xb3, 0x01, 0x4a, 0x52, 0x0a, 0x03, 0x32, 0x30, 0x31, 0x12, 0x4b, 0x0a, 0x23, 0x45, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x20, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65,
+	0x64, 0x20, 0x66, 0x6f, 0x72, 0x20, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x24, 0x0a, 0x22, 0x1a, 0x20, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4a, 0x5d, 0x0a, 0x03, 0x34, 0x32, 0x32, 0x12, 0x56,
+	0x0a, 0x3c, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x20, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x2c, 0x20, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x20, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x20, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x16,
+	0x0a, 0x14, 0x1a, 0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x3a, 0x01, 0x2a, 0x22,
+	0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
+	0x74, 0x65, 0x12, 0x6e, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x26, 0x2e,
+	0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x7c, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75,
+	0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x20,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x12, 0x18, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f,
+	0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d,
+	0x12, 0x8f, 0x01, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x45, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e,
+	0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x24, 0x3a, 0x01,
+	0x2a, 0x22, 0x1f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x3a, 0x63, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x12, 0x82, 0x01, 0x0a, 0x11, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x45, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75,
+	0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e,
+	0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x28, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x14, 0x3a, 0x01, 0x2a, 0x22, 0x0f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f,
+	0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x42, 0x40, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x65, 0x6c, 0x6f, 0x34, 0x79, 0x61, 0x2f, 0x65, 0x64,
+	0x75, 0x2d, 0x64, 0x69, 0x73, 0x74, 0x2d, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65,
+	0x2d, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
+	0x74, 0x6f, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -492,33 +1336,64 @@ func file_calculator_v1_public_proto_rawDescGZIP() []byte {
 	return file_calculator_v1_public_proto_rawDescData
 }
 
-var file_calculator_v1_public_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_calculator_v1_public_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_calculator_v1_public_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_calculator_v1_public_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_calculator_v1_public_proto_goTypes = []any{
-	(ExpressionStatus)(0),           // 0: calculator.v1.ExpressionStatus
-	(*CalculateRequest)(nil),        // 1: calculator.v1.CalculateRequest
-	(*CalculateResponse)(nil),       // 2: calculator.v1.CalculateResponse
-	(*Expression)(nil),              // 3: calculator.v1.Expression
-	(*ListExpressionsResponse)(nil), // 4: calculator.v1.ListExpressionsResponse
-	(*GetExpressionRequest)(nil),    // 5: calculator.v1.GetExpressionRequest
-	(*GetExpressionResponse)(nil),   // 6: calculator.v1.GetExpressionResponse
-	(*emptypb.Empty)(nil),           // 7: google.protobuf.Empty
+	(ExpressionStatus)(0),                  // 0: calculator.v1.ExpressionStatus
+	(ParseErrorReason)(0),                  // 1: calculator.v1.ParseErrorReason
+	(*ParseError)(nil),                     // 2: calculator.v1.ParseError
+	(*CalculateRequest)(nil),               // 3: calculator.v1.CalculateRequest
+	(*CalculateResponse)(nil),              // 4: calculator.v1.CalculateResponse
+	(*Expression)(nil),                     // 5: calculator.v1.Expression
+	(*ListExpressionsResponse)(nil),        // 6: calculator.v1.ListExpressionsResponse
+	(*GetExpressionRequest)(nil),           // 7: calculator.v1.GetExpressionRequest
+	(*GetExpressionResponse)(nil),          // 8: calculator.v1.GetExpressionResponse
+	(*CancelExpressionRequest)(nil),        // 9: calculator.v1.CancelExpressionRequest
+	(*CancelExpressionResponse)(nil),       // 10: calculator.v1.CancelExpressionResponse
+	(*ExplainExpressionRequest)(nil),       // 11: calculator.v1.ExplainExpressionRequest
+	(*ExplainExpressionResponse)(nil),      // 12: calculator.v1.ExplainExpressionResponse
+	nil,                                    // 13: calculator.v1.CalculateRequest.VariablesEntry
+	nil,                                    // 14: calculator.v1.Expression.VariablesEntry
+	nil,                                    // 15: calculator.v1.Expression.FunctionsEntry
+	nil,                                    // 16: calculator.v1.ExplainExpressionRequest.VariablesEntry
+	(*ExplainExpressionResponse_Task)(nil), // 17: calculator.v1.ExplainExpressionResponse.Task
+	(Arithmetic)(0),                        // 18: calculator.v1.Arithmetic
+	(*durationpb.Duration)(nil),            // 19: google.protobuf.Duration
+	(TaskOperation)(0),                     // 20: calculator.v1.TaskOperation
+	(*emptypb.Empty)(nil),                  // 21: google.protobuf.Empty
 }
 var file_calculator_v1_public_proto_depIdxs = []int32{
-	0, // 0: calculator.v1.Expression.status:type_name -> calculator.v1.ExpressionStatus
-	3, // 1: calculator.v1.ListExpressionsResponse.expressions:type_name -> calculator.v1.Expression
-	3, // 2: calculator.v1.GetExpressionResponse.expression:type_name -> calculator.v1.Expression
-	1, // 3: calculator.v1.CalculatorService.Calculate:input_type -> calculator.v1.CalculateRequest
-	7, // 4: calculator.v1.CalculatorService.ListExpressions:input_type -> google.protobuf.Empty
-	5, // 5: calculator.v1.CalculatorService.GetExpression:input_type -> calculator.v1.GetExpressionRequest
-	2, // 6: calculator.v1.CalculatorService.Calculate:output_type -> calculator.v1.CalculateResponse
-	4, // 7: calculator.v1.CalculatorService.ListExpressions:output_type -> calculator.v1.ListExpressionsResponse
-	6, // 8: calculator.v1.CalculatorService.GetExpression:output_type -> calculator.v1.GetExpressionResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	1,  // 0: calculator.v1.ParseError.reason:type_name -> calculator.v1.ParseErrorReason
+	13, // 1: calculator.v1.CalculateRequest.variables:type_name -> calculator.v1.CalculateRequest.VariablesEntry
+	18, // 2: calculator.v1.CalculateRequest.arithmetic:type_name -> calculator.v1.Arithmetic
+	0,  // 3: calculator.v1.Expression.status:type_name -> calculator.v1.ExpressionStatus
+	14, // 4: calculator.v1.Expression.variables:type_name -> calculator.v1.Expression.VariablesEntry
+	18, // 5: calculator.v1.Expression.arithmetic:type_name -> calculator.v1.Arithmetic
+	15, // 6: calculator.v1.Expression.functions:type_name -> calculator.v1.Expression.FunctionsEntry
+	5,  // 7: calculator.v1.ListExpressionsResponse.expressions:type_name -> calculator.v1.Expression
+	5,  // 8: calculator.v1.GetExpressionResponse.expression:type_name -> calculator.v1.Expression
+	5,  // 9: calculator.v1.CancelExpressionResponse.expression:type_name -> calculator.v1.Expression
+	16, // 10: calculator.v1.ExplainExpressionRequest.variables:type_name -> calculator.v1.ExplainExpressionRequest.VariablesEntry
+	18, // 11: calculator.v1.ExplainExpressionRequest.arithmetic:type_name -> calculator.v1.Arithmetic
+	17, // 12: calculator.v1.ExplainExpressionResponse.tasks:type_name -> calculator.v1.ExplainExpressionResponse.Task
+	19, // 13: calculator.v1.ExplainExpressionResponse.estimated_time:type_name -> google.protobuf.Duration
+	20, // 14: calculator.v1.ExplainExpressionResponse.Task.operation:type_name -> calculator.v1.TaskOperation
+	19, // 15: calculator.v1.ExplainExpressionResponse.Task.operation_time:type_name -> google.protobuf.Duration
+	3,  // 16: calculator.v1.CalculatorService.Calculate:input_type -> calculator.v1.CalculateRequest
+	21, // 17: calculator.v1.CalculatorService.ListExpressions:input_type -> google.protobuf.Empty
+	7,  // 18: calculator.v1.CalculatorService.GetExpression:input_type -> calculator.v1.GetExpressionRequest
+	9,  // 19: calculator.v1.CalculatorService.CancelExpression:input_type -> calculator.v1.CancelExpressionRequest
+	11, // 20: calculator.v1.CalculatorService.ExplainExpression:input_type -> calculator.v1.ExplainExpressionRequest
+	4,  // 21: calculator.v1.CalculatorService.Calculate:output_type -> calculator.v1.CalculateResponse
+	6,  // 22: calculator.v1.CalculatorService.ListExpressions:output_type -> calculator.v1.ListExpressionsResponse
+	8,  // 23: calculator.v1.CalculatorService.GetExpression:output_type -> calculator.v1.GetExpressionResponse
+	10, // 24: calculator.v1.CalculatorService.CancelExpression:output_type -> calculator.v1.CancelExpressionResponse
+	12, // 25: calculator.v1.CalculatorService.ExplainExpression:output_type -> calculator.v1.ExplainExpressionResponse
+	21, // [21:26] is the sub-list for method output_type
+	16, // [16:21] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_calculator_v1_public_proto_init() }
@@ -526,13 +1401,16 @@ func file_calculator_v1_public_proto_init() {
 	if File_calculator_v1_public_proto != nil {
 		return
 	}
+	file_calculator_v1_agent_proto_init()
+	file_calculator_v1_public_proto_msgTypes[1].OneofWrappers = []any{}
+	file_calculator_v1_public_proto_msgTypes[9].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_calculator_v1_public_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,9 +20,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CalculatorService_Calculate_FullMethodName       = "/calculator.v1.CalculatorService/Calculate"
-	CalculatorService_ListExpressions_FullMethodName = "/calculator.v1.CalculatorService/ListExpressions"
-	CalculatorService_GetExpression_FullMethodName   = "/calculator.v1.CalculatorService/GetExpression"
+	CalculatorService_Calculate_FullMethodName         = "/calculator.v1.CalculatorService/Calculate"
+	CalculatorService_ListExpressions_FullMethodName   = "/calculator.v1.CalculatorService/ListExpressions"
+	CalculatorService_GetExpression_FullMethodName     = "/calculator.v1.CalculatorService/GetExpression"
+	CalculatorService_CancelExpression_FullMethodName  = "/calculator.v1.CalculatorService/CancelExpression"
+	CalculatorService_ExplainExpression_FullMethodName = "/calculator.v1.CalculatorService/ExplainExpression"
 )
 
 // CalculatorServiceClient is the client API for CalculatorService service.
@@ -37,6 +39,12 @@ type CalculatorServiceClient interface {
 	ListExpressions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListExpressionsResponse, error)
 	// Returns a specific expression by its identifier.
 	GetExpression(ctx context.Context, in *GetExpressionRequest, opts ...grpc.CallOption) (*GetExpressionResponse, error)
+	// Stops calculation of an expression: its pending tasks are removed from the queue,
+	// and results of the tasks being processed are discarded.
+	// Fails with FAILED_PRECONDITION if the expression has already been completed or failed.
+	CancelExpression(ctx context.Context, in *CancelExpressionRequest, opts ...grpc.CallOption) (*CancelExpressionResponse, error)
+	// Shows how an expression would be calculated without submitting it.
+	ExplainExpression(ctx context.Context, in *ExplainExpressionRequest, opts ...grpc.CallOption) (*ExplainExpressionResponse, error)
 }
 
 type calculatorServiceClient struct {
@@ -77,6 +85,26 @@ func (c *calculatorServiceClient) GetExpression(ctx context.Context, in *GetExpr
 	return out, nil
 }
 
+func (c *calculatorServiceClient) CancelExpression(ctx context.Context, in *CancelExpressionRequest, opts ...grpc.CallOption) (*CancelExpressionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelExpressionResponse)
+	err := c.cc.Invoke(ctx, CalculatorService_CancelExpression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) ExplainExpression(ctx context.Context, in *ExplainExpressionRequest, opts ...grpc.CallOption) (*ExplainExpressionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainExpressionResponse)
+	err := c.cc.Invoke(ctx, CalculatorService_ExplainExpression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CalculatorServiceServer is the server API for CalculatorService service.
 // All implementations should embed UnimplementedCalculatorServiceServer
 // for forward compatibility.
@@ -89,6 +117,12 @@ type CalculatorServiceServer interface {
 	ListExpressions(context.Context, *emptypb.Empty) (*ListExpressionsResponse, error)
 	// Returns a specific expression by its identifier.
 	GetExpression(context.Context, *GetExpressionRequest) (*GetExpressionResponse, error)
+	// Stops calculation of an expression: its pending tasks are removed from the queue,
+	// and results of the tasks being processed are discarded.
+	// Fails with FAILED_PRECONDITION if the expression has already been completed or failed.
+	CancelExpression(context.Context, *CancelExpressionRequest) (*CancelExpressionResponse, error)
+	// Shows how an expression would be calculated without submitting it.
+	ExplainExpression(context.Context, *ExplainExpressionRequest) (*ExplainExpressionResponse, error)
 }
 
 // UnimplementedCalculatorServiceServer should be embedded to have
@@ -107,6 +141,12 @@ func (UnimplementedCalculatorServiceServer) ListExpressions(context.Context, *em
 func (UnimplementedCalculatorServiceServer) GetExpression(context.Context, *GetExpressionRequest) (*GetExpressionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetExpression not implemented")
 }
+func (UnimplementedCalculatorServiceServer) CancelExpression(context.Context, *CancelExpressionRequest) (*CancelExpressionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelExpression not implemented")
+}
+func (UnimplementedCalculatorServiceServer) ExplainExpression(context.Context, *ExplainExpressionRequest) (*ExplainExpressionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainExpression not implemented")
+}
 func (UnimplementedCalculatorServiceServer) testEmbeddedByValue() {}
 
 // UnsafeCalculatorServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -181,6 +221,42 @@ func _CalculatorService_GetExpression_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CalculatorService_CancelExpression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelExpressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).CancelExpression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_CancelExpression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).CancelExpression(ctx, req.(*CancelExpressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_ExplainExpression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainExpressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).ExplainExpression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_ExplainExpression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).ExplainExpression(ctx, req.(*ExplainExpressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CalculatorService_ServiceDesc is the grpc.ServiceDesc for CalculatorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -200,6 +276,14 @@ var CalculatorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetExpression",
 			Handler:    _CalculatorService_GetExpression_Handler,
 		},
+		{
+			MethodName: "CancelExpression",
+			Handler:    _CalculatorService_CancelExpression_Handler,
+		},
+		{
+			MethodName: "ExplainExpression",
+			Handler:    _CalculatorService_ExplainExpression_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "calculator/v1/public.proto",